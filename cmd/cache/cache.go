@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/logger"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"cache",
+		"Manage the response cache: stats or clear",
+		[]cli.Flag{},
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	responses, err := commit.Cache(c.configManager.Config.Cache)
+	if err != nil {
+		return err
+	}
+
+	switch action := ctx.Args().First(); action {
+	case "", "stats":
+		stats, err := responses.Stats()
+		if err != nil {
+			return fmt.Errorf("error reading cache: %v", err)
+		}
+
+		fmt.Printf("Directory: %s\n", stats.Dir)
+		if c.configManager.Config.Cache.Disabled {
+			fmt.Println("Status:    disabled")
+		}
+		fmt.Printf("Entries:   %d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Printf("Size:      %s of %s\n", formatSize(stats.Size), formatSize(stats.MaxSize))
+		fmt.Printf("TTL:       %s\n", stats.TTL)
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest:    %s\n", stats.Oldest.Format(time.DateTime))
+			fmt.Printf("Newest:    %s\n", stats.Newest.Format(time.DateTime))
+		}
+		return nil
+	case "clear":
+		removed, err := responses.Clear()
+		if err != nil {
+			return fmt.Errorf("error clearing cache: %v", err)
+		}
+		fmt.Printf("Removed %d cached responses\n", removed)
+		return nil
+	default:
+		return fmt.Errorf("unknown cache action: %s. Must be 'stats' or 'clear'", action)
+	}
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
@@ -0,0 +1,145 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/changelog"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/logger"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	polish        bool
+	all           bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"changelog",
+		"Generate a Keep a Changelog section from the commits in [from..to] (defaults to the last tag..HEAD)",
+		lo.Flatten([][]cli.Flag{{
+			&cli.StringFlag{
+				Name:  "version",
+				Usage: "version heading of the generated section",
+				Value: "Unreleased",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "prepend the section to a changelog file (e.g. CHANGELOG.md) instead of printing it",
+			},
+			&cli.BoolFlag{
+				Name:        "polish",
+				Usage:       "rewrite commit subjects into user-facing release notes with the configured provider",
+				Destination: &c.polish,
+			},
+			&cli.BoolFlag{
+				Name:        "all",
+				Usage:       "include docs, chore and other non user-facing types under \"Other\"",
+				Destination: &c.all,
+			},
+		}, cmd.CacheFlags(), cmd.GenerationFlags()}),
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
+	revRange := ctx.Args().First()
+	if revRange == "" {
+		tag, err := gitClient.LastTag()
+		if err != nil {
+			return err
+		}
+		revRange = "HEAD"
+		if tag != "" {
+			revRange = tag + "..HEAD"
+		}
+	}
+
+	commits, err := gitClient.ListCommits(revRange)
+	if err != nil {
+		return err
+	}
+
+	conv, err := convention.Get(c.configManager.Config.Convention)
+	if err != nil {
+		return err
+	}
+
+	version := ctx.String("version")
+	date := ""
+	if version != "Unreleased" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	release := changelog.Build(commits, conv, strings.TrimPrefix(version, "v"), date, c.all)
+	if release.Skipped > 0 {
+		c.logger.DebugLog("Skipped commits not following the "+conv.Name()+" convention", fmt.Sprintf("%d", release.Skipped))
+	}
+
+	if c.polish {
+		if err := c.configManager.LoadLocalRules(); err != nil {
+			c.logger.DebugLog("Error loading local rules", err.Error())
+		}
+
+		cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
+		generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
+		if err != nil {
+			return fmt.Errorf("failed to initialize commit message generator: %v", err)
+		}
+
+		if err := changelog.Polish(generator, &release, c.configManager.GetRules()); err != nil {
+			return err
+		}
+	}
+
+	section := release.Markdown()
+
+	output := ctx.String("output")
+	if output == "" {
+		fmt.Print(section)
+		return nil
+	}
+
+	existing, err := os.ReadFile(output)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading %s: %v", output, err)
+	}
+
+	if err := os.WriteFile(output, []byte(changelog.Prepend(string(existing), section)), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", output, err)
+	}
+
+	fmt.Printf("Changelog for %s written to %s\n", revRange, output)
+	return nil
+}
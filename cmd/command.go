@@ -1,6 +1,17 @@
 package cmd
 
-import "github.com/urfave/cli/v2"
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// OpenRepository opens the repository commands operate on, the one containing the
+// working directory, with the configured backend. Tests can replace it to run
+// handlers against a fake git.Client.
+var OpenRepository = func(backend string) (git.Client, error) {
+	return git.OpenBackend(backend, ".")
+}
 
 // Command is the interface that all commands must implement
 type Command interface {
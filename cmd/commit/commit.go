@@ -2,7 +2,9 @@ package commit
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/samber/lo"
 	"github.com/urfave/cli/v2"
 
 	"github.com/dacsang97/aigc/cmd"
@@ -10,13 +12,17 @@ import (
 	"github.com/dacsang97/aigc/internal/config"
 	"github.com/dacsang97/aigc/internal/git"
 	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/style"
 )
 
 type Command struct {
 	*cmd.BaseCommand
 	configManager *config.Manager
 	logger        *logger.Logger
+	learnStyle    bool
 	push          bool
+	amend         bool
+	force         bool
 }
 
 func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
@@ -28,7 +34,7 @@ func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
 	baseCmd := cmd.NewBaseCommand(
 		"commit",
 		"Generate and create a commit",
-		[]cli.Flag{
+		lo.Flatten([][]cli.Flag{{
 			&cli.BoolFlag{
 				Name:        "push",
 				Aliases:     []string{"p"},
@@ -40,7 +46,23 @@ func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
 				Aliases: []string{"m"},
 				Usage:   "provide commit message hint (in any language)",
 			},
-		},
+			&cli.BoolFlag{
+				Name:        "learn-style",
+				Usage:       "follow the commit style of the repository's recent history",
+				Destination: &c.learnStyle,
+			},
+			&cli.BoolFlag{
+				Name:        "amend",
+				Usage:       "regenerate the message of the last commit instead of creating a new one",
+				Destination: &c.amend,
+			},
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "allow amending a commit that has already been pushed",
+				Destination: &c.force,
+			},
+		}, cmd.LanguageFlags(), cmd.CacheFlags(), cmd.StructuredFlags(), cmd.TrailerFlags(), cmd.SigningFlags(), cmd.PushFlags(), cmd.GenerationFlags()}),
 		c.handle,
 	)
 
@@ -49,39 +71,70 @@ func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
 }
 
 func (c *Command) handle(ctx *cli.Context) error {
+	// Initialize git client
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
 	// Load local rules if they exist
 	if err := c.configManager.LoadLocalRules(); err != nil {
 		c.logger.DebugLog("Error loading local rules", err.Error())
 	}
 
-	// Initialize git client
-	gitClient := git.New(c.push)
-
 	// Get git changes
-	changes, err := gitClient.GetStagedChanges()
+	var changes string
+	if c.amend {
+		changes, err = c.amendChanges(gitClient)
+	} else {
+		changes, err = gitClient.GetStagedChanges()
+	}
 	if err != nil {
 		return err
 	}
 
 	c.logger.DebugLog("Git changes detected", changes)
 
+	if err := cmd.ApplySigning(ctx, c.configManager.Config.Signing, gitClient); err != nil {
+		return err
+	}
+
 	// Get user's commit message hint if provided
 	userMessage := ctx.String("message")
 	if userMessage != "" {
 		c.logger.DebugLog("User provided commit message hint", userMessage)
 	}
 
+	cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
 	// Initialize commit message generator
-	generator, err := commit.New(commit.ProviderConfig{
-		Provider: c.configManager.Config.Provider.Provider,
-		Model:    c.configManager.Config.Provider.Model,
-		APIKey:   c.configManager.Config.Provider.APIKey,
-		Endpoint: c.configManager.Config.Provider.Endpoint,
-	})
+	generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
 	if err != nil {
 		return fmt.Errorf("failed to initialize commit message generator: %v", err)
 	}
 
+	rev := ""
+	if c.amend {
+		rev = "HEAD"
+	}
+	if err := generator.LoadRepositoryContext(gitClient, rev, c.configManager.TemplateDir); err != nil {
+		return err
+	}
+
+	if c.learnStyle || c.configManager.Config.Style.Learn {
+		profile, err := style.LearnFromHistory(gitClient, c.configManager.Config.Style.Sample, c.configManager.Config.Style.Examples)
+		if err != nil {
+			c.logger.DebugLog("Error learning commit style", err.Error())
+		} else {
+			c.logger.DebugLog("Learned commit style", fmt.Sprintf("%s (%d examples)", profile.Convention, len(profile.Examples)))
+			generator.UseStyle(profile)
+		}
+	}
+
 	// Generate commit message
 	commitMsg, err := generator.Generate(changes, userMessage, c.configManager.GetRules())
 	if err != nil {
@@ -89,14 +142,64 @@ func (c *Command) handle(ctx *cli.Context) error {
 	}
 
 	c.logger.DebugLog("Generated commit message", commitMsg)
+	if generator.CacheHits() > 0 {
+		fmt.Fprintln(os.Stderr, "Using the cached message for these changes; run with --no-cache to generate a new one")
+	}
+
+	for _, problem := range generator.Validate(commitMsg) {
+		fmt.Fprintf(os.Stderr, "Warning: message does not follow the %s convention: %s\n", generator.Convention().Name(), problem)
+	}
+
+	trailers, err := cmd.Trailers(ctx, c.configManager.Config.Trailers, gitClient)
+	if err != nil {
+		return err
+	}
+	if commitMsg, err = gitClient.AddTrailers(commitMsg, trailers); err != nil {
+		return err
+	}
 
-	// Commit changes
-	if err := gitClient.Commit(commitMsg); err != nil {
+	commitMsg, err = cmd.PassCommitMsgHook(c.configManager.Config.Hooks, gitClient, generator, c.logger, changes, userMessage, c.configManager.GetRules(), trailers, commitMsg)
+	if err != nil {
 		return err
 	}
 
-	fmt.Println("Successfully committed changes with message:")
-	fmt.Println(commitMsg)
+	if c.amend {
+		if err := gitClient.Amend(commitMsg); err != nil {
+			return err
+		}
+
+		fmt.Println("Successfully amended commit with message:")
+		fmt.Println(commitMsg)
+	} else {
+		// Commit changes
+		if err := gitClient.Commit(commitMsg); err != nil {
+			return err
+		}
+
+		fmt.Println("Successfully committed changes with message:")
+		fmt.Println(commitMsg)
+	}
+
+	if c.push {
+		if err := cmd.Push(gitClient, cmd.PushOptions(ctx)); err != nil {
+			return fmt.Errorf("the commit was created, but pushing it failed: %v", err)
+		}
+	}
 
 	return nil
 }
+
+// amendChanges returns the diff of HEAD against its parent, refusing pushed commits unless forced
+func (c *Command) amendChanges(gitClient git.Client) (string, error) {
+	if !c.force {
+		pushed, err := gitClient.IsPushed("HEAD")
+		if err != nil {
+			return "", err
+		}
+		if pushed {
+			return "", fmt.Errorf("HEAD has already been pushed; use --force to amend it anyway")
+		}
+	}
+
+	return gitClient.GetCommitChanges("HEAD")
+}
@@ -2,13 +2,21 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/samber/lo"
 	"github.com/urfave/cli/v2"
 
 	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
 	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
 	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/models"
+	"github.com/dacsang97/aigc/internal/prompt"
+	"github.com/dacsang97/aigc/internal/provider"
 )
 
 type Command struct {
@@ -35,6 +43,10 @@ func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
 				Name:  "model",
 				Usage: "Set the AI model",
 			},
+			&cli.BoolFlag{
+				Name:  "skip-model-check",
+				Usage: "Save --model without checking it against the provider's model list",
+			},
 			&cli.StringFlag{
 				Name:  "api-key",
 				Usage: "Set the API key",
@@ -43,10 +55,30 @@ func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
 				Name:  "endpoint",
 				Usage: "Set custom API endpoint URL (optional)",
 			},
+			&cli.StringFlag{
+				Name:  "api-style",
+				Usage: "Set the API of OpenAI-compatible providers (chat or responses)",
+			},
 			&cli.BoolFlag{
 				Name:  "debug",
 				Usage: "Enable debug mode",
 			},
+			&cli.StringFlag{
+				Name:  "convention",
+				Usage: "Set the commit convention (" + strings.Join(convention.Names(), ", ") + ")",
+			},
+			&cli.StringFlag{
+				Name:  "language",
+				Usage: "Set the language of generated messages (e.g. English, vi, ja)",
+			},
+			&cli.BoolFlag{
+				Name:  "learn-style",
+				Usage: "Learn the commit style from the repository's recent history",
+			},
+			&cli.StringFlag{
+				Name:  "git-backend",
+				Usage: "Set the git backend (exec runs the git binary, go works without git installed)",
+			},
 		},
 		c.runConfig,
 	)
@@ -71,11 +103,6 @@ func (c *Command) runConfig(ctx *cli.Context) error {
 		updated = true
 	}
 
-	if model := ctx.String("model"); model != "" {
-		c.configManager.Config.Provider.Model = model
-		updated = true
-	}
-
 	if apiKey := ctx.String("api-key"); apiKey != "" {
 		c.configManager.Config.Provider.APIKey = apiKey
 		updated = true
@@ -86,18 +113,70 @@ func (c *Command) runConfig(ctx *cli.Context) error {
 		updated = true
 	}
 
+	// The model is checked after the provider, key and endpoint of the same invocation
+	// are applied, so they are used to list the models
+	if model := ctx.String("model"); model != "" {
+		if !ctx.Bool("skip-model-check") {
+			if err := c.checkModel(model); err != nil {
+				return err
+			}
+		}
+		c.configManager.Config.Provider.Model = model
+		updated = true
+	}
+
+	if style := ctx.String("api-style"); style != "" {
+		if style != provider.APIStyleChat && style != provider.APIStyleResponses {
+			return fmt.Errorf("invalid API style: %s. Must be '%s' or '%s'", style, provider.APIStyleChat, provider.APIStyleResponses)
+		}
+		c.configManager.Config.Provider.APIStyle = style
+		updated = true
+	}
+
 	if ctx.IsSet("debug") {
 		c.configManager.Config.Debug = ctx.Bool("debug")
 		updated = true
 	}
 
+	if name := ctx.String("convention"); name != "" {
+		conv, err := convention.Get(name)
+		if err != nil {
+			return err
+		}
+		c.configManager.Config.Convention = conv.Name()
+		updated = true
+	}
+
+	if language := ctx.String("language"); language != "" {
+		c.configManager.Config.Language = prompt.LanguageName(language)
+		updated = true
+	}
+
+	if ctx.IsSet("learn-style") {
+		c.configManager.Config.Style.Learn = ctx.Bool("learn-style")
+		updated = true
+	}
+
+	if backend := ctx.String("git-backend"); backend != "" {
+		if backend != git.BackendExec && backend != git.BackendGo {
+			return fmt.Errorf("invalid git backend: %s, must be %s or %s", backend, git.BackendExec, git.BackendGo)
+		}
+		c.configManager.Config.Git.Backend = backend
+		updated = true
+	}
+
 	if !updated {
 		fmt.Printf("Current configuration:\n")
 		fmt.Printf("  Provider: %s\n", c.configManager.Config.Provider.Provider)
 		fmt.Printf("  Model: %s\n", c.configManager.Config.Provider.Model)
 		fmt.Printf("  API Key: %s\n", maskAPIKey(c.configManager.Config.Provider.APIKey))
 		fmt.Printf("  Endpoint: %s\n", c.configManager.Config.Provider.Endpoint)
+		fmt.Printf("  API Style: %s\n", lo.Ternary(c.configManager.Config.Provider.APIStyle != "", c.configManager.Config.Provider.APIStyle, provider.APIStyleChat))
 		fmt.Printf("  Debug: %v\n", c.configManager.Config.Debug)
+		fmt.Printf("  Convention: %s\n", lo.Ternary(c.configManager.Config.Convention != "", c.configManager.Config.Convention, convention.Default().Name()))
+		fmt.Printf("  Language: %s\n", prompt.LanguageName(c.configManager.Config.Language))
+		fmt.Printf("  Learn Style: %v\n", c.configManager.Config.Style.Learn)
+		fmt.Printf("  Git Backend: %s\n", lo.Ternary(c.configManager.Config.Git.Backend != "", c.configManager.Config.Git.Backend, git.BackendExec))
 		return nil
 	}
 
@@ -109,6 +188,24 @@ func (c *Command) runConfig(ctx *cli.Context) error {
 	return nil
 }
 
+// checkModel fails if the provider does not offer the model, suggesting close matches.
+// A model list that cannot be fetched only warns, so the model can be set offline.
+func (c *Command) checkModel(model string) error {
+	list, err := commit.ListModels(c.configManager.Config, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not check the model against the provider's list: %v\n", err)
+		return nil
+	}
+	if _, ok := models.Find(list, model); ok {
+		return nil
+	}
+
+	if suggestions := models.Suggest(list, model, 3); len(suggestions) > 0 {
+		return fmt.Errorf("%s does not offer the model %s. Did you mean: %s? (use --skip-model-check to save it anyway)", c.configManager.Config.Provider.Provider, model, strings.Join(suggestions, ", "))
+	}
+	return fmt.Errorf("%s does not offer the model %s; run 'aigc models' to list its models (use --skip-model-check to save it anyway)", c.configManager.Config.Provider.Provider, model)
+}
+
 func maskAPIKey(key string) string {
 	if len(key) <= 8 {
 		return "********"
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+// GenerationFlags are the flags of commands that generate text, overriding the
+// generation section of the config
+func GenerationFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.Float64Flag{
+			Name:  "temperature",
+			Usage: "sampling temperature, 0 to 2",
+		},
+		&cli.IntFlag{
+			Name:  "max-tokens",
+			Usage: "maximum number of tokens in the response",
+		},
+		&cli.Float64Flag{
+			Name:  "top-p",
+			Usage: "nucleus sampling probability mass, 0 to 1",
+		},
+		&cli.StringSliceFlag{
+			Name:  "stop",
+			Usage: "sequence that ends the response (repeatable)",
+		},
+		&cli.StringFlag{
+			Name:  "reasoning-effort",
+			Usage: "reasoning effort of reasoning models (minimal, low, medium or high)",
+		},
+	}
+}
+
+// CacheFlags are the flags of commands whose provider responses are cached
+func CacheFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "no-cache",
+			Usage: "call the provider even if a cached response for the same prompt exists",
+		},
+	}
+}
+
+// LanguageFlags are the flags of commands that write in the configured language
+func LanguageFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "lang",
+			Usage: "language of the generated message, overriding the config (e.g. English, vi, ja)",
+		},
+	}
+}
+
+// StructuredFlags are the flags of commands that generate commit messages
+func StructuredFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "structured",
+			Usage: "ask the model for a JSON message and format it for the convention",
+		},
+	}
+}
+
+// ApplyGeneration copies the GenerationFlags, CacheFlags, LanguageFlags and
+// StructuredFlags that were set into the config and logs the settings the configured
+// provider does not support. Flags a command does not define are left alone.
+func ApplyGeneration(ctx *cli.Context, cfg *config.Config, logger *logger.Logger) {
+	if lang := ctx.String("lang"); lang != "" {
+		cfg.Language = lang
+	}
+	if ctx.Bool("no-cache") {
+		cfg.Cache.Disabled = true
+	}
+	if ctx.Bool("structured") {
+		cfg.Generation.Structured = true
+	}
+	if ctx.IsSet("temperature") {
+		temperature := ctx.Float64("temperature")
+		cfg.Generation.Temperature = &temperature
+	}
+	if ctx.IsSet("max-tokens") {
+		cfg.Generation.MaxTokens = ctx.Int("max-tokens")
+	}
+	if ctx.IsSet("top-p") {
+		topP := ctx.Float64("top-p")
+		cfg.Generation.TopP = &topP
+	}
+	if ctx.IsSet("stop") {
+		cfg.Generation.Stop = ctx.StringSlice("stop")
+	}
+	if ctx.IsSet("reasoning-effort") {
+		cfg.Generation.ReasoningEffort = ctx.String("reasoning-effort")
+	}
+
+	providerConfig := provider.Config{
+		Provider: cfg.Provider.Provider,
+		Model:    cfg.Provider.Model,
+		APIStyle: cfg.Provider.APIStyle,
+		Generation: provider.Generation{
+			Temperature:     cfg.Generation.Temperature,
+			MaxTokens:       cfg.Generation.MaxTokens,
+			TopP:            cfg.Generation.TopP,
+			Stop:            cfg.Generation.Stop,
+			ReasoningEffort: cfg.Generation.ReasoningEffort,
+		},
+	}
+	if ignored := providerConfig.IgnoredSettings(); len(ignored) > 0 {
+		logger.DebugLog("Generation settings not supported by "+cfg.Provider.Provider+" "+cfg.Provider.Model+" are ignored", strings.Join(ignored, ", "))
+	}
+}
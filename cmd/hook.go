@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/logger"
+)
+
+// defaultHookRetries is how many times a message rejected by the commit-msg hook is
+// regenerated when hooks.retries is not set
+const defaultHookRetries = 2
+
+// PassCommitMsgHook runs the commit-msg hook on the message and, while it is rejected,
+// regenerates the message with the hook's output as feedback, up to the configured
+// number of retries. It returns the first message the hook accepts.
+func PassCommitMsgHook(cfg config.HookConfig, gitClient git.Client, generator *commit.Generator, logger *logger.Logger, changes, userMessage string, rules []string, trailers []git.Trailer, commitMsg string) (string, error) {
+	retries := defaultHookRetries
+	if cfg.Retries != nil {
+		retries = max(*cfg.Retries, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := gitClient.CheckCommitMessage(commitMsg)
+		var hookErr *git.HookError
+		if !errors.As(err, &hookErr) {
+			return commitMsg, err
+		}
+		if attempt >= retries {
+			return "", fmt.Errorf("the commit-msg hook rejected the generated message:\n%s\n\nLast message:\n%s", hookErr.Output, commitMsg)
+		}
+
+		fmt.Fprintf(os.Stderr, "The commit-msg hook rejected the message, regenerating (%d/%d):\n%s\n", attempt+1, retries, hookErr.Output)
+		logger.DebugLog("Commit message rejected by hook", hookErr.Output)
+
+		if commitMsg, err = generator.Regenerate(changes, userMessage, rules, commitMsg, hookErr.Output); err != nil {
+			return "", err
+		}
+		if commitMsg, err = gitClient.AddTrailers(commitMsg, trailers); err != nil {
+			return "", err
+		}
+		logger.DebugLog("Regenerated commit message", commitMsg)
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"models",
+		"List the models offered by the provider",
+		[]cli.Flag{
+			&cli.StringFlag{
+				Name:  "provider",
+				Usage: "list the models of another provider (openai, anthropic, openrouter or custom)",
+			},
+			&cli.StringFlag{
+				Name:  "endpoint",
+				Usage: "endpoint of the provider, e.g. an Ollama server's http://localhost:11434/v1/chat/completions",
+			},
+			&cli.BoolFlag{
+				Name:  "refresh",
+				Usage: "fetch the list again instead of using the one cached for a day",
+			},
+		},
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	cfg := c.configManager.Config
+	// The configured key and endpoint belong to the configured provider only
+	if name := strings.ToLower(ctx.String("provider")); name != "" && name != cfg.Provider.Provider {
+		cfg.Provider.Provider = name
+		cfg.Provider.APIKey = ""
+		cfg.Provider.Endpoint = ""
+	}
+	if endpoint := ctx.String("endpoint"); endpoint != "" {
+		cfg.Provider.Endpoint = endpoint
+	}
+
+	list, err := commit.ListModels(cfg, ctx.Bool("refresh"))
+	if err != nil {
+		return err
+	}
+	c.logger.DebugLog("Listed models of "+cfg.Provider.Provider, fmt.Sprintf("%d models", len(list)))
+
+	if len(list) == 0 {
+		fmt.Printf("%s offers no models\n", cfg.Provider.Provider)
+		return nil
+	}
+
+	detailed := false
+	for _, m := range list {
+		if m.ContextLength > 0 || m.InputPrice != nil {
+			detailed = true
+			break
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if detailed {
+		fmt.Fprintln(w, "MODEL\tCONTEXT\tINPUT $/M\tOUTPUT $/M")
+	}
+	for _, m := range list {
+		id := m.ID
+		if id == c.configManager.Config.Provider.Model {
+			id += " (current)"
+		}
+		if detailed {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, formatContext(m), formatPrice(m.InputPrice), formatPrice(m.OutputPrice))
+		} else {
+			fmt.Fprintln(w, id)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d models\n", len(list))
+	return nil
+}
+
+func formatContext(m provider.Model) string {
+	if m.ContextLength == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", m.ContextLength)
+}
+
+func formatPrice(price *float64) string {
+	if price == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.4g", *price)
+}
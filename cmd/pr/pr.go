@@ -0,0 +1,193 @@
+package pr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/logger"
+)
+
+// templatePaths are the locations GitHub looks for a pull request template, relative to the repo root
+var templatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	noTemplate    bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"pr",
+		"Generate a pull request title and description for the commits since [base]",
+		lo.Flatten([][]cli.Flag{{
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "provide a hint about the pull request (in any language)",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write the title and description to a file instead of stdout",
+			},
+			&cli.StringFlag{
+				Name:  "template",
+				Usage: "pull request template to fill in (defaults to the repository's template, if any)",
+			},
+			&cli.BoolFlag{
+				Name:        "no-template",
+				Usage:       "ignore the repository's pull request template",
+				Destination: &c.noTemplate,
+			},
+		}, cmd.LanguageFlags(), cmd.CacheFlags(), cmd.GenerationFlags()}),
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
+	// Load local rules if they exist
+	if err := c.configManager.LoadLocalRules(); err != nil {
+		c.logger.DebugLog("Error loading local rules", err.Error())
+	}
+
+	base := ctx.Args().First()
+	if base == "" {
+		var err error
+		if base, err = gitClient.DefaultBase(); err != nil {
+			return err
+		}
+	}
+
+	log, err := gitClient.GetLog(base + "..HEAD")
+	if err != nil {
+		return err
+	}
+
+	changes, err := gitClient.GetBranchChanges(base)
+	if err != nil {
+		return err
+	}
+
+	c.logger.DebugLog("Branch commits detected", log)
+
+	repoTemplate, err := c.loadTemplate(gitClient, ctx.String("template"))
+	if err != nil {
+		return err
+	}
+
+	cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
+	generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit message generator: %v", err)
+	}
+
+	messages := generator.Prompt().BuildPRMessages(log, changes, repoTemplate, ctx.String("message"), c.configManager.GetRules())
+	reply, err := generator.Complete(messages)
+	if err != nil {
+		return err
+	}
+
+	title, body := splitTitle(reply)
+	if title == "" {
+		return fmt.Errorf("no pull request title generated")
+	}
+
+	c.logger.DebugLog("Generated pull request", reply)
+
+	result := title + "\n\n" + body + "\n"
+
+	output := ctx.String("output")
+	if output == "" {
+		fmt.Print(result)
+		return nil
+	}
+
+	if err := os.WriteFile(output, []byte(result), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", output, err)
+	}
+
+	fmt.Printf("Pull request written to %s\n", output)
+	return nil
+}
+
+// loadTemplate reads the explicit template, or the repository's own unless disabled
+func (c *Command) loadTemplate(gitClient git.Client, path string) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading template: %v", err)
+		}
+		return string(data), nil
+	}
+
+	if c.noTemplate {
+		return "", nil
+	}
+
+	for _, candidate := range templatePaths {
+		data, err := os.ReadFile(filepath.Join(gitClient.Root(), candidate))
+		if err == nil {
+			c.logger.DebugLog("Using pull request template", candidate)
+			return string(data), nil
+		}
+	}
+
+	return "", nil
+}
+
+// splitTitle separates the first line of the reply, the title, from the Markdown body
+func splitTitle(reply string) (string, string) {
+	title, body, _ := strings.Cut(commit.CleanMessage(reply), "\n")
+
+	title = strings.TrimSpace(strings.TrimLeft(title, "# "))
+	title = strings.TrimSpace(strings.TrimPrefix(title, "Title:"))
+	title = trimQuotes(title)
+
+	return title, strings.TrimSpace(body)
+}
+
+// trimQuotes removes one pair of quotes some models wrap the title in
+func trimQuotes(title string) string {
+	for _, quote := range []string{`"`, "'", "`"} {
+		if len(title) >= 2 && strings.HasPrefix(title, quote) && strings.HasSuffix(title, quote) {
+			return strings.TrimSpace(title[1 : len(title)-1])
+		}
+	}
+	return title
+}
@@ -0,0 +1,30 @@
+package pr
+
+import "testing"
+
+func TestSplitTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		title string
+		body  string
+	}{
+		{"plain", "Add search endpoint\n\n## Summary\n\n- adds search", "Add search endpoint", "## Summary\n\n- adds search"},
+		{"heading", "# Add search endpoint\n\nBody", "Add search endpoint", "Body"},
+		{"title prefix", "Title: Add search endpoint\nBody", "Add search endpoint", "Body"},
+		{"code fence", "```markdown\nAdd search endpoint\n\nBody\n```", "Add search endpoint", "Body"},
+		{"double quotes", "\"Add search endpoint\"\n\nBody", "Add search endpoint", "Body"},
+		{"heading and quotes", "# 'Add search endpoint'\n\nBody", "Add search endpoint", "Body"},
+		{"inner code span kept", "Rename `Run` to `Start`\n\nBody", "Rename `Run` to `Start`", "Body"},
+		{"title only", "Add search endpoint", "Add search endpoint", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := splitTitle(tt.reply)
+			if title != tt.title || body != tt.body {
+				t.Errorf("splitTitle() = %q, %q, want %q, %q", title, body, tt.title, tt.body)
+			}
+		})
+	}
+}
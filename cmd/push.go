@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// PushFlags are the flags of commands that can push their result
+func PushFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "remote",
+			Usage: "remote to push to with --push (default: the branch's upstream, or origin)",
+		},
+		&cli.BoolFlag{
+			Name:  "force-with-lease",
+			Usage: "with --push, overwrite the remote branch unless someone else pushed to it",
+		},
+		&cli.BoolFlag{
+			Name:  "tags",
+			Usage: "with --push, push tags as well",
+		},
+	}
+}
+
+// PushOptions reads the PushFlags of a command
+func PushOptions(ctx *cli.Context) git.PushOptions {
+	return git.PushOptions{
+		Remote:         ctx.String("remote"),
+		ForceWithLease: ctx.Bool("force-with-lease"),
+		Tags:           ctx.Bool("tags"),
+	}
+}
+
+// Push pushes the current branch, showing git's progress, and reports where it went
+func Push(gitClient git.Client, opts git.PushOptions) error {
+	opts.Progress = os.Stderr
+	result, err := gitClient.Push(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pushed %s to %s\n", result.Branch, result.Remote)
+	if result.SetUpstream {
+		fmt.Printf("Branch %s now tracks %s/%s\n", result.Branch, result.Remote, result.Branch)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/changelog"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/release"
+	"github.com/dacsang97/aigc/internal/semver"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	tag           bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"release",
+		"Recommend the next semantic version from the commits since the last release",
+		lo.Flatten([][]cli.Flag{{
+			&cli.StringFlag{
+				Name:  "pre",
+				Usage: "pre-release channel (e.g. alpha, beta, rc)",
+			},
+			&cli.StringFlag{
+				Name:  "bump",
+				Usage: "force the bump level instead of deriving it from commits (major, minor or patch)",
+			},
+			&cli.BoolFlag{
+				Name:        "tag",
+				Usage:       "create an annotated tag with an AI-written release summary",
+				Destination: &c.tag,
+			},
+		}, cmd.CacheFlags(), cmd.GenerationFlags()}),
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
+	conv, err := convention.Get(c.configManager.Config.Convention)
+	if err != nil {
+		return err
+	}
+
+	tags, err := gitClient.ListTags()
+	if err != nil {
+		return err
+	}
+	versions := release.Versions(tags)
+
+	previous := "the beginning"
+	revRange := "HEAD"
+	stable, found := release.LatestStable(versions)
+	if found {
+		previous = stable.String()
+		revRange = previous + "..HEAD"
+	}
+
+	commits, err := gitClient.ListCommits(revRange)
+	if err != nil {
+		return err
+	}
+
+	level := release.LevelFor(commits, conv, stable)
+	if bump := ctx.String("bump"); bump != "" {
+		if level, err = semver.ParseLevel(bump); err != nil {
+			return err
+		}
+	}
+
+	next, err := release.Next(versions, level, ctx.String("pre"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Last release: %s\n", previous)
+	fmt.Printf("Commits since: %d\n", len(commits))
+	fmt.Printf("Bump: %s\n", level)
+	fmt.Printf("Next version: %s\n", next)
+
+	if !c.tag {
+		return nil
+	}
+
+	// Load local rules if they exist
+	if err := c.configManager.LoadLocalRules(); err != nil {
+		c.logger.DebugLog("Error loading local rules", err.Error())
+	}
+
+	notes := changelog.Build(commits, conv, strings.TrimPrefix(next.String(), "v"), time.Now().Format("2006-01-02"), false)
+
+	cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
+	generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit message generator: %v", err)
+	}
+
+	messages := generator.Prompt().BuildReleaseMessages(next.String(), previous, notes.Markdown(), c.configManager.GetRules())
+	summary, err := generator.Complete(messages)
+	if err != nil {
+		return err
+	}
+	summary = strings.TrimSpace(summary)
+
+	c.logger.DebugLog("Generated release summary", summary)
+
+	if err := gitClient.CreateTag(next.String(), summary); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nCreated tag %s with message:\n%s\n", next, summary)
+	fmt.Printf("Push it with: git push origin %s\n", next)
+	return nil
+}
@@ -0,0 +1,363 @@
+package reword
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/editor"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/style"
+)
+
+// proposalMarker starts each commit block in the editable list of proposals
+const proposalMarker = "==> "
+
+// proposalHeader matches a block header: the marker, a full commit hash and the old subject
+var proposalHeader = regexp.MustCompile(`^` + regexp.QuoteMeta(proposalMarker) + `([0-9a-f]{40})(?: |$)`)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	learnStyle    bool
+	force         bool
+	yes           bool
+	push          bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"reword",
+		"Regenerate the message of an existing commit (e.g. aigc reword HEAD~2) or a range of commits",
+		lo.Flatten([][]cli.Flag{{
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "provide commit message hint (in any language)",
+			},
+			&cli.StringFlag{
+				Name:    "range",
+				Aliases: []string{"r"},
+				Usage:   "reword every commit in a revision range (e.g. origin/main..HEAD)",
+			},
+			&cli.BoolFlag{
+				Name:        "learn-style",
+				Usage:       "follow the commit style of the repository's recent history",
+				Destination: &c.learnStyle,
+			},
+			&cli.BoolFlag{
+				Name:        "yes",
+				Aliases:     []string{"y"},
+				Usage:       "apply range proposals without opening the editor",
+				Destination: &c.yes,
+			},
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "allow rewriting commits that have already been pushed",
+				Destination: &c.force,
+			},
+			&cli.BoolFlag{
+				Name:        "push",
+				Aliases:     []string{"p"},
+				Usage:       "push the rewritten branch, with --force-with-lease",
+				Destination: &c.push,
+			},
+		}, cmd.LanguageFlags(), cmd.CacheFlags(), cmd.StructuredFlags(), cmd.TrailerFlags(), cmd.SigningFlags(), cmd.PushFlags(), cmd.GenerationFlags()}),
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	revRange := ctx.String("range")
+	rev := ctx.Args().First()
+	if rev == "" && revRange == "" {
+		return fmt.Errorf("missing commit to reword, e.g. 'aigc reword HEAD~2' or 'aigc reword --range origin/main..HEAD'")
+	}
+	if rev != "" && revRange != "" {
+		return fmt.Errorf("cannot combine a commit argument with --range")
+	}
+
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
+	// Load local rules if they exist
+	if err := c.configManager.LoadLocalRules(); err != nil {
+		c.logger.DebugLog("Error loading local rules", err.Error())
+	}
+
+	if err := cmd.ApplySigning(ctx, c.configManager.Config.Signing, gitClient); err != nil {
+		return err
+	}
+
+	cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
+	generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit message generator: %v", err)
+	}
+
+	if c.learnStyle || c.configManager.Config.Style.Learn {
+		profile, err := style.LearnFromHistory(gitClient, c.configManager.Config.Style.Sample, c.configManager.Config.Style.Examples)
+		if err != nil {
+			c.logger.DebugLog("Error learning commit style", err.Error())
+		} else {
+			c.logger.DebugLog("Learned commit style", fmt.Sprintf("%s (%d examples)", profile.Convention, len(profile.Examples)))
+			generator.UseStyle(profile)
+		}
+	}
+
+	userMessage := ctx.String("message")
+	if userMessage != "" {
+		c.logger.DebugLog("User provided commit message hint", userMessage)
+	}
+
+	trailers, err := cmd.Trailers(ctx, c.configManager.Config.Trailers, gitClient)
+	if err != nil {
+		return err
+	}
+
+	if revRange != "" {
+		return c.rewordRange(ctx, gitClient, generator, revRange, userMessage, trailers)
+	}
+
+	sha, err := gitClient.ResolveCommit(rev)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkNotPushed(gitClient, sha, rev); err != nil {
+		return err
+	}
+
+	commitMsg, err := c.generate(gitClient, generator, sha, userMessage, trailers)
+	if err != nil {
+		return err
+	}
+
+	backupRef, err := gitClient.Reword(sha, commitMsg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully reworded %s with message:\n", rev)
+	fmt.Println(commitMsg)
+	fmt.Printf("Previous HEAD saved as %s\n", backupRef)
+
+	return c.pushRewritten(ctx, gitClient)
+}
+
+// rewordRange generates a message for every commit in revRange, lets the user
+// review them in one editable list and rewrites the branch in a single pass
+func (c *Command) rewordRange(ctx *cli.Context, gitClient git.Client, generator *commit.Generator, revRange, userMessage string, trailers []git.Trailer) error {
+	commits, err := gitClient.ListCommits(revRange)
+	if err != nil {
+		return err
+	}
+
+	for _, cm := range commits {
+		if err := c.checkNotPushed(gitClient, cm.Hash, cm.Hash[:7]); err != nil {
+			return err
+		}
+	}
+
+	proposals := make(map[string]string, len(commits))
+	for i, cm := range commits {
+		fmt.Printf("Generating message for %s (%d/%d): %s\n", cm.Hash[:7], i+1, len(commits), cm.Subject)
+
+		commitMsg, err := c.generate(gitClient, generator, cm.Hash, userMessage, trailers)
+		if err != nil {
+			return err
+		}
+		proposals[cm.Hash] = strings.TrimSpace(commitMsg)
+	}
+
+	if !c.yes {
+		edited, err := editor.Edit(formatProposals(revRange, commits, proposals))
+		if err != nil {
+			return err
+		}
+
+		proposals, err = parseProposals(edited, commits)
+		if err != nil {
+			return err
+		}
+		if len(proposals) == 0 {
+			fmt.Println("No proposals left, nothing was rewritten")
+			return nil
+		}
+
+		// Edited messages are the user's own, so a rejection is reported rather than regenerated
+		for _, cm := range commits {
+			message, ok := proposals[cm.Hash]
+			if !ok {
+				continue
+			}
+			if err := gitClient.CheckCommitMessage(message); err != nil {
+				return fmt.Errorf("edited message for %s: %w", cm.Hash[:7], err)
+			}
+		}
+	}
+
+	backupRef, err := gitClient.RewriteMessages(proposals)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully reworded %d commit(s) in %s\n", len(proposals), revRange)
+	fmt.Printf("Previous HEAD saved as %s\n", backupRef)
+
+	return c.pushRewritten(ctx, gitClient)
+}
+
+// pushRewritten pushes the rewritten branch when --push is set. Rewritten history is
+// never a fast-forward of what was pushed before, so the push always uses
+// --force-with-lease, which refuses to overwrite commits someone else pushed.
+func (c *Command) pushRewritten(ctx *cli.Context, gitClient git.Client) error {
+	if !c.push {
+		return nil
+	}
+
+	opts := cmd.PushOptions(ctx)
+	opts.ForceWithLease = true
+	if err := cmd.Push(gitClient, opts); err != nil {
+		return fmt.Errorf("the commits were reworded, but pushing them failed: %v", err)
+	}
+	return nil
+}
+
+func (c *Command) checkNotPushed(gitClient git.Client, sha, name string) error {
+	if c.force {
+		return nil
+	}
+
+	pushed, err := gitClient.IsPushed(sha)
+	if err != nil {
+		return err
+	}
+	if pushed {
+		return fmt.Errorf("commit %s has already been pushed; use --force to rewrite it anyway", name)
+	}
+	return nil
+}
+
+// generate writes a new message for the commit, with the same trailers and commit-msg
+// hook check as aigc commit
+func (c *Command) generate(gitClient git.Client, generator *commit.Generator, sha, userMessage string, trailers []git.Trailer) (string, error) {
+	changes, err := gitClient.GetCommitChanges(sha)
+	if err != nil {
+		return "", err
+	}
+
+	if err := generator.LoadRepositoryContext(gitClient, sha, c.configManager.TemplateDir); err != nil {
+		return "", err
+	}
+
+	c.logger.DebugLog("Commit changes detected", changes)
+
+	commitMsg, err := generator.Generate(changes, userMessage, c.configManager.GetRules())
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.DebugLog("Generated commit message", commitMsg)
+
+	for _, problem := range generator.Validate(commitMsg) {
+		fmt.Fprintf(os.Stderr, "Warning: message does not follow the %s convention: %s\n", generator.Convention().Name(), problem)
+	}
+
+	if commitMsg, err = gitClient.AddTrailers(commitMsg, trailers); err != nil {
+		return "", err
+	}
+
+	return cmd.PassCommitMsgHook(c.configManager.Config.Hooks, gitClient, generator, c.logger, changes, userMessage, c.configManager.GetRules(), trailers, commitMsg)
+}
+
+// formatProposals renders the proposals as a single document for the editor
+func formatProposals(revRange string, commits []git.Commit, proposals map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Proposed messages for %s\n", revRange)
+	b.WriteString("#\n")
+	b.WriteString("# Each block starts with a \"" + proposalMarker + "<hash> <old subject>\" line followed by the new message.\n")
+	b.WriteString("# Edit messages freely, or delete a whole block to keep the original message.\n")
+	b.WriteString("# This header is ignored; lines starting with '#' inside a block are kept. Delete everything to abort.\n")
+
+	for _, cm := range commits {
+		fmt.Fprintf(&b, "\n%s%s %s\n%s\n", proposalMarker, cm.Hash, cm.Subject, proposals[cm.Hash])
+	}
+
+	return b.String()
+}
+
+// parseProposals reads the edited document back into messages keyed by commit hash
+func parseProposals(content string, commits []git.Commit) (map[string]string, error) {
+	known := make(map[string]bool, len(commits))
+	for _, cm := range commits {
+		known[cm.Hash] = true
+	}
+
+	proposals := map[string]string{}
+	var current string
+	var lines []string
+
+	flush := func() error {
+		if current == "" {
+			return nil
+		}
+		message := strings.TrimSpace(strings.Join(lines, "\n"))
+		if message == "" {
+			return fmt.Errorf("empty message for commit %s", current[:7])
+		}
+		proposals[current] = message
+		return nil
+	}
+
+	// Only the header before the first block is a comment; generated messages may
+	// legitimately contain lines starting with '#', such as issue references. A block
+	// starts only at a header naming one of the commits, so a message line that merely
+	// begins with the marker stays part of the message.
+	for _, line := range strings.Split(content, "\n") {
+		match := proposalHeader.FindStringSubmatch(line)
+		if match == nil || !known[match[1]] {
+			if current != "" {
+				lines = append(lines, line)
+			}
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		current = match[1]
+		lines = nil
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
@@ -0,0 +1,48 @@
+package reword
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+func TestParseProposalsRoundTrip(t *testing.T) {
+	a := strings.Repeat("a", 40)
+	b := strings.Repeat("b", 40)
+	commits := []git.Commit{{Hash: a, Subject: "wip"}, {Hash: b, Subject: "more wip"}}
+	proposals := map[string]string{
+		a: "feat: add search\n\n# Closes #12\n==> not a header",
+		b: "fix: handle nil config",
+	}
+
+	got, err := parseProposals(formatProposals("main..HEAD", commits, proposals), commits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, proposals) {
+		t.Errorf("parseProposals() = %q, want %q", got, proposals)
+	}
+}
+
+func TestParseProposalsDeletedBlock(t *testing.T) {
+	a := strings.Repeat("a", 40)
+	b := strings.Repeat("b", 40)
+	commits := []git.Commit{{Hash: a}, {Hash: b}}
+
+	got, err := parseProposals("# header\n\n==> "+b+" old subject\nfix: keep this one\n", commits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{b: "fix: keep this one"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseProposals() = %q, want %q", got, want)
+	}
+}
+
+func TestParseProposalsEmptyMessage(t *testing.T) {
+	a := strings.Repeat("a", 40)
+	if _, err := parseProposals("==> "+a+" wip\n\n", []git.Commit{{Hash: a}}); err == nil {
+		t.Error("parseProposals() succeeded, want an error for an empty message")
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// SigningFlags are the flags of commands that create or rewrite commits
+func SigningFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "sign",
+			Usage: "GPG/SSH-sign the commits",
+		},
+		&cli.BoolFlag{
+			Name:  "no-sign",
+			Usage: "do not sign the commits, even if commit.gpgsign is set",
+		},
+		&cli.StringFlag{
+			Name:    "sign-key",
+			Aliases: []string{"S"},
+			Usage:   "sign with this key ID instead of user.signingkey (implies --sign)",
+		},
+	}
+}
+
+// ApplySigning resolves the SigningFlags against the signing section of the config
+// and sets the result on the repository
+func ApplySigning(ctx *cli.Context, cfg config.SigningConfig, gitClient git.Client) error {
+	signing, err := commit.Signing(cfg, ctx.Bool("sign"), ctx.Bool("no-sign"), ctx.String("sign-key"))
+	if err != nil {
+		return err
+	}
+	gitClient.SetSigning(signing)
+	return nil
+}
@@ -0,0 +1,151 @@
+package split
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/split"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	yes           bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"split",
+		"Split the staged changes into multiple logical commits",
+		lo.Flatten([][]cli.Flag{{
+			&cli.BoolFlag{
+				Name:        "yes",
+				Aliases:     []string{"y"},
+				Usage:       "create the proposed commits without asking for confirmation",
+				Destination: &c.yes,
+			},
+		}, cmd.LanguageFlags(), cmd.CacheFlags(), cmd.StructuredFlags(), cmd.TrailerFlags(), cmd.SigningFlags(), cmd.GenerationFlags()}),
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
+	// Load local rules if they exist
+	if err := c.configManager.LoadLocalRules(); err != nil {
+		c.logger.DebugLog("Error loading local rules", err.Error())
+	}
+
+	hunks, err := gitClient.GetStagedHunks()
+	if errors.Is(err, git.ErrNoChanges) {
+		if status, statusErr := gitClient.Status(); statusErr == nil && len(status) > 0 {
+			return fmt.Errorf("%w; split works on the index, stage changes with `git add` first", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	c.logger.DebugLog("Staged hunks detected", fmt.Sprintf("%d", len(hunks)))
+
+	if err := cmd.ApplySigning(ctx, c.configManager.Config.Signing, gitClient); err != nil {
+		return err
+	}
+
+	cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
+	generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit message generator: %v", err)
+	}
+
+	if err := generator.LoadTickets(gitClient); err != nil {
+		return err
+	}
+
+	groups, err := split.Plan(generator, hunks, c.configManager.GetRules())
+	if err != nil {
+		return err
+	}
+
+	trailers, err := cmd.Trailers(ctx, c.configManager.Config.Trailers, gitClient)
+	if err != nil {
+		return err
+	}
+	for i := range groups {
+		if groups[i].Message, err = gitClient.AddTrailers(groups[i].Message, trailers); err != nil {
+			return err
+		}
+	}
+
+	printGroups(groups)
+
+	if !c.yes && !confirm(fmt.Sprintf("Create these %d commits?", len(groups))) {
+		fmt.Println("Aborted, the index was left unchanged")
+		return nil
+	}
+
+	if err := split.Apply(gitClient, groups); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully created %d commits\n", len(groups))
+	return nil
+}
+
+func printGroups(groups []split.Group) {
+	for i, group := range groups {
+		fmt.Printf("\nCommit %d/%d:\n", i+1, len(groups))
+		for _, line := range strings.Split(group.Message, "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+
+		counts := map[string]int{}
+		var files []string
+		for _, h := range group.Hunks {
+			if counts[h.File] == 0 {
+				files = append(files, h.File)
+			}
+			counts[h.File]++
+		}
+		for _, file := range files {
+			fmt.Printf("  - %s (%d hunk(s))\n", file, counts[file])
+		}
+	}
+	fmt.Println()
+}
+
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
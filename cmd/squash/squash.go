@@ -0,0 +1,128 @@
+package squash
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/logger"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	write         bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"squash-message",
+		"Generate a squash-merge message for the commits since [base]",
+		lo.Flatten([][]cli.Flag{{
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "provide commit message hint (in any language)",
+			},
+			&cli.BoolFlag{
+				Name:        "write",
+				Aliases:     []string{"w"},
+				Usage:       "write the message to .git/SQUASH_MSG instead of stdout",
+				Destination: &c.write,
+			},
+		}, cmd.LanguageFlags(), cmd.CacheFlags(), cmd.GenerationFlags()}),
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	gitClient, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+	if err != nil {
+		return err
+	}
+
+	if err := c.configManager.LoadLocalConfig(gitClient.Root()); err != nil {
+		return err
+	}
+
+	// Load local rules if they exist
+	if err := c.configManager.LoadLocalRules(); err != nil {
+		c.logger.DebugLog("Error loading local rules", err.Error())
+	}
+
+	base := ctx.Args().First()
+	if base == "" {
+		var err error
+		if base, err = gitClient.DefaultBase(); err != nil {
+			return err
+		}
+	}
+
+	log, err := gitClient.GetLog(base + "..HEAD")
+	if err != nil {
+		return err
+	}
+
+	changes, err := gitClient.GetBranchChanges(base)
+	if err != nil {
+		return err
+	}
+
+	c.logger.DebugLog("Branch commits detected", log)
+
+	cmd.ApplyGeneration(ctx, &c.configManager.Config, c.logger)
+
+	generator, err := commit.NewFromConfig(c.configManager.Config, gitClient.Root())
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit message generator: %v", err)
+	}
+
+	if err := generator.LoadTickets(gitClient); err != nil {
+		return err
+	}
+
+	messages, err := generator.Prompt().BuildSquashMessages(log, changes, ctx.String("message"), c.configManager.GetRules())
+	if err != nil {
+		return err
+	}
+
+	squashMsg, err := generator.Complete(messages)
+	if err != nil {
+		return err
+	}
+	squashMsg = generator.AddTickets(strings.TrimSpace(squashMsg))
+
+	c.logger.DebugLog("Generated squash message", squashMsg)
+
+	if !c.write {
+		fmt.Println(squashMsg)
+		return nil
+	}
+
+	path, err := gitClient.GitPath("SQUASH_MSG")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(squashMsg+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+
+	fmt.Printf("Squash message written to %s\n", path)
+	return nil
+}
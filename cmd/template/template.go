@@ -0,0 +1,192 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/editor"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/prompt"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+	repo          bool
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"template",
+		"Manage prompt templates: show [name], edit <name> or reset <name> ("+strings.Join(prompt.TemplateNames, ", ")+")",
+		[]cli.Flag{
+			&cli.BoolFlag{
+				Name:        "repo",
+				Usage:       "edit or reset the repository's template in .aigc/templates instead of the global one",
+				Destination: &c.repo,
+			},
+		},
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	action := ctx.Args().Get(0)
+	name := ctx.Args().Get(1)
+
+	switch action {
+	case "", "show":
+		return c.show(name)
+	case "edit":
+		return c.edit(name)
+	case "reset":
+		return c.reset(name)
+	default:
+		return fmt.Errorf("unknown template action: %s. Must be 'show', 'edit' or 'reset'", action)
+	}
+}
+
+// show prints the effective templates and where each one was loaded from
+func (c *Command) show(name string) error {
+	names := prompt.TemplateNames
+	if name != "" {
+		if _, err := prompt.DefaultTemplate(name); err != nil {
+			return err
+		}
+		names = []string{name}
+	}
+
+	templates, err := prompt.LoadTemplates(c.dirs()...)
+	if err != nil {
+		return err
+	}
+
+	for i, n := range names {
+		text, err := templateText(templates.Sources[n], n)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("# %s (%s)\n%s\n", n, templates.Sources[n], strings.TrimRight(text, "\n"))
+	}
+	return nil
+}
+
+// edit opens the template in the editor, starting from the effective one, and only
+// saves it if it parses and renders
+func (c *Command) edit(name string) error {
+	if _, err := prompt.DefaultTemplate(name); err != nil {
+		return err
+	}
+
+	path, err := c.targetPath(name)
+	if err != nil {
+		return err
+	}
+
+	templates, err := prompt.LoadTemplates(c.dirs()...)
+	if err != nil {
+		return err
+	}
+
+	current, err := templateText(templates.Sources[name], name)
+	if err != nil {
+		return err
+	}
+
+	edited, err := editor.Edit(current)
+	if err != nil {
+		return err
+	}
+
+	if edited == current {
+		fmt.Println("Template unchanged")
+		return nil
+	}
+
+	if _, err := prompt.ParseTemplate(name, edited); err != nil {
+		return fmt.Errorf("invalid template, not saved: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Template %s saved to %s\n", name, path)
+	return nil
+}
+
+// reset removes the override so the built-in (or global) template applies again
+func (c *Command) reset(name string) error {
+	if _, err := prompt.DefaultTemplate(name); err != nil {
+		return err
+	}
+
+	path, err := c.targetPath(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			fmt.Printf("Template %s is not overridden in %s\n", name, filepath.Dir(path))
+			return nil
+		}
+		return err
+	}
+
+	fmt.Printf("Removed %s\n", path)
+	return nil
+}
+
+// dirs returns the template search path; outside a repository only the global directory is used
+func (c *Command) dirs() []string {
+	root := ""
+	if repo, err := cmd.OpenRepository(c.configManager.Config.Git.Backend); err == nil {
+		root = repo.Root()
+	}
+	return prompt.TemplateDirs(root, c.configManager.TemplateDir)
+}
+
+func (c *Command) targetPath(name string) (string, error) {
+	dir := c.configManager.TemplateDir
+	if c.repo {
+		repo, err := cmd.OpenRepository(c.configManager.Config.Git.Backend)
+		if err != nil {
+			return "", err
+		}
+		dir = prompt.TemplateDirs(repo.Root(), "")[0]
+	}
+	return filepath.Join(dir, name+".tmpl"), nil
+}
+
+func templateText(source, name string) (string, error) {
+	if source == "built-in" {
+		return prompt.DefaultTemplate(name)
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// TrailerFlags are the flags of commands that write commit messages
+func TrailerFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "co-author",
+			Usage: "add a Co-authored-by trailer, by alias from trailers.team or as \"Name <email>\" (repeatable)",
+		},
+		&cli.BoolFlag{
+			Name:    "signoff",
+			Aliases: []string{"s"},
+			Usage:   "add a Signed-off-by trailer (overrides trailers.sign_off)",
+		},
+	}
+}
+
+// Trailers resolves the TrailerFlags against the trailers section of the config.
+// Trailers are added after generation so the model never invents them.
+func Trailers(ctx *cli.Context, cfg config.TrailerConfig, gitClient git.Client) ([]git.Trailer, error) {
+	signOff := cfg.SignOff
+	if ctx.IsSet("signoff") {
+		signOff = ctx.Bool("signoff")
+	}
+	return commit.Trailers(gitClient, cfg, ctx.StringSlice("co-author"), signOff)
+}
@@ -0,0 +1,121 @@
+package usage
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/dacsang97/aigc/cmd"
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/logger"
+	"github.com/dacsang97/aigc/internal/usage"
+)
+
+type Command struct {
+	*cmd.BaseCommand
+	configManager *config.Manager
+	logger        *logger.Logger
+}
+
+func New(configManager *config.Manager, logger *logger.Logger) cmd.Command {
+	c := &Command{
+		configManager: configManager,
+		logger:        logger,
+	}
+
+	baseCmd := cmd.NewBaseCommand(
+		"usage",
+		"Report the tokens and estimated cost of provider requests",
+		[]cli.Flag{
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "start of the report: days (30d), a duration (12h) or a date (2024-01-31)",
+				Value: "30d",
+			},
+			&cli.StringFlag{
+				Name:  "by",
+				Usage: "group the report by " + strings.Join(usage.Groupings, ", "),
+				Value: "model",
+			},
+		},
+		c.handle,
+	)
+
+	c.BaseCommand = baseCmd
+	return c
+}
+
+func (c *Command) handle(ctx *cli.Context) error {
+	cfg := c.configManager.Config.Usage
+	now := time.Now()
+
+	since, err := usage.ParseSince(ctx.String("since"), now)
+	if err != nil {
+		return err
+	}
+	budget, err := commit.Budget(cfg)
+	if err != nil {
+		return err
+	}
+	store, err := commit.UsageStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	records, err := store.Since(since)
+	if err != nil {
+		return fmt.Errorf("error reading usage: %v", err)
+	}
+	rows, total, err := usage.Summarize(records, ctx.String("by"))
+	if err != nil {
+		return err
+	}
+
+	if cfg.Disabled {
+		fmt.Println("Usage tracking is disabled; enable it by removing usage.disabled from the config")
+	}
+	fmt.Printf("Usage since %s\n\n", since.Format(time.DateOnly))
+
+	if len(rows) == 0 {
+		fmt.Println("No requests recorded")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\tCALLS\tINPUT\tOUTPUT\tAVG LATENCY\tCOST\n", strings.ToUpper(ctx.String("by")))
+		for _, row := range append(rows, total) {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", row.Key, row.Calls, row.InputTokens, row.OutputTokens, row.AverageLatency().Round(time.Millisecond), formatCost(row))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if total.Unpriced > 0 {
+			fmt.Printf("\n* %d requests used models missing from the price table; add them under usage.prices\n", total.Unpriced)
+		}
+	}
+
+	if budget.Monthly > 0 {
+		spent, err := store.MonthToDate(now)
+		if err != nil {
+			return fmt.Errorf("error reading usage: %v", err)
+		}
+		action := budget.Action
+		if action == "" {
+			action = usage.BudgetWarn
+		}
+		fmt.Printf("\nMonthly budget: $%.2f of $%.2f spent (%s when reached)\n", spent, budget.Monthly, action)
+	}
+	return nil
+}
+
+// formatCost marks costs that leave out unpriced requests
+func formatCost(row usage.Row) string {
+	cost := fmt.Sprintf("$%.4f", row.Cost)
+	if row.Unpriced > 0 {
+		cost += "*"
+	}
+	return cost
+}
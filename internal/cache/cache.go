@@ -0,0 +1,285 @@
+// Package cache stores provider responses on disk so repeating a generation with the
+// same prompt does not bill it again.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTTL is how long an entry is served when no TTL is configured
+	DefaultTTL = 7 * 24 * time.Hour
+	// DefaultMaxSize is the total size of the entries kept when no limit is configured
+	DefaultMaxSize int64 = 50 << 20
+
+	// pruneInterval is how often Put prunes the cache
+	pruneInterval = time.Hour
+
+	entryExt = ".json"
+	// pruneStamp is touched after every prune; it has no entry extension so it is
+	// never mistaken for an entry
+	pruneStamp = "pruned"
+)
+
+// Cache is a content-addressed store of responses, one file per key. Entries expire
+// after the TTL and the least recently used ones are evicted once the total size is
+// over the limit.
+type Cache struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+}
+
+// Entry is a cached response
+type Entry struct {
+	Provider string    `json:"provider"`
+	Model    string    `json:"model"`
+	Created  time.Time `json:"created"`
+	Response string    `json:"response"`
+}
+
+// Stats describes the contents of the cache
+type Stats struct {
+	Dir     string
+	Entries int
+	Expired int
+	Size    int64
+	MaxSize int64
+	TTL     time.Duration
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// New returns a cache stored in dir. A zero ttl or maxSize uses the default.
+func New(dir string, ttl time.Duration, maxSize int64) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Cache{dir: dir, ttl: ttl, maxSize: maxSize}
+}
+
+// Key hashes the parts into a cache key. Parts are length-prefixed so different
+// splits of the same bytes never collide.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Dir returns the directory entries are stored in
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the entry stored under key, if it exists and has not expired. A hit
+// marks the entry as recently used.
+func (c *Cache) Get(key string) (Entry, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil || c.expired(entry) {
+		os.Remove(path)
+		return Entry{}, false
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return entry, true
+}
+
+// Put stores the entry under key. At most once per pruneInterval it also evicts
+// entries to stay within the limits, since pruning reads every entry.
+func (c *Cache) Put(key string, entry Entry) error {
+	if entry.Created.IsZero() {
+		entry.Created = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so a concurrent Get never reads a partial entry
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if !c.pruneDue() {
+		return nil
+	}
+	_, err = c.Prune()
+	return err
+}
+
+// pruneDue reports whether the last prune is older than pruneInterval
+func (c *Cache) pruneDue() bool {
+	info, err := os.Stat(filepath.Join(c.dir, pruneStamp))
+	return err != nil || time.Since(info.ModTime()) > pruneInterval
+}
+
+// Prune removes expired entries, then the least recently used ones until the cache
+// fits in its size limit. It returns the number of entries removed.
+func (c *Cache) Prune() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	var size int64
+	kept := files[:0]
+	for _, f := range files {
+		if time.Since(f.created) > c.ttl {
+			if os.Remove(f.path) == nil {
+				removed++
+			}
+			continue
+		}
+		size += f.size
+		kept = append(kept, f)
+	}
+
+	sort.Slice(kept, func(i, j int) bool { return kept[i].used.Before(kept[j].used) })
+	for _, f := range kept {
+		if size <= c.maxSize {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			removed++
+			size -= f.size
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(c.dir, pruneStamp), nil, 0600); err != nil && !os.IsNotExist(err) {
+		return removed, err
+	}
+	return removed, nil
+}
+
+// Stats reports the number, size and age of the stored entries
+func (c *Cache) Stats() (Stats, error) {
+	stats := Stats{Dir: c.dir, MaxSize: c.maxSize, TTL: c.ttl}
+
+	files, err := c.files()
+	if err != nil {
+		return stats, err
+	}
+	for _, f := range files {
+		stats.Entries++
+		stats.Size += f.size
+		if time.Since(f.created) > c.ttl {
+			stats.Expired++
+		}
+		if stats.Oldest.IsZero() || f.created.Before(stats.Oldest) {
+			stats.Oldest = f.created
+		}
+		if f.created.After(stats.Newest) {
+			stats.Newest = f.created
+		}
+	}
+	return stats, nil
+}
+
+// Clear removes every entry and returns how many were removed
+func (c *Cache) Clear() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, f := range files {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+entryExt)
+}
+
+func (c *Cache) expired(entry Entry) bool {
+	return time.Since(entry.Created) > c.ttl
+}
+
+type file struct {
+	path    string
+	size    int64
+	created time.Time
+	used    time.Time
+}
+
+// files lists the stored entries. The creation time is read from the entry, falling
+// back to the modification time for entries that cannot be parsed.
+func (c *Cache) files() ([]file, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []file
+	for _, d := range dirEntries {
+		if d.IsDir() || !strings.HasSuffix(d.Name(), entryExt) {
+			continue
+		}
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+
+		f := file{
+			path:    filepath.Join(c.dir, d.Name()),
+			size:    info.Size(),
+			created: info.ModTime(),
+			used:    info.ModTime(),
+		}
+		if data, err := os.ReadFile(f.path); err == nil {
+			var entry Entry
+			if json.Unmarshal(data, &entry) == nil && !entry.Created.IsZero() {
+				f.created = entry.Created
+			}
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
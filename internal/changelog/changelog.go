@@ -0,0 +1,190 @@
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// sectionOrder lists the Keep a Changelog sections in the order they are rendered
+var sectionOrder = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security", "Other"}
+
+// sectionForType maps Conventional Commit types to Keep a Changelog sections.
+// Types missing here only appear under "Other" when all types are included. Conventions
+// without types (jira, plain) infer them from the subject's leading verb.
+var sectionForType = map[string]string{
+	"feat":      "Added",
+	"fix":       "Fixed",
+	"perf":      "Changed",
+	"refactor":  "Changed",
+	"revert":    "Changed",
+	"deprecate": "Deprecated",
+	"remove":    "Removed",
+	"security":  "Security",
+}
+
+// Entry is one line of the changelog
+type Entry struct {
+	Hash         string
+	Type         string
+	Scope        string
+	Description  string
+	Breaking     bool
+	BreakingNote string
+}
+
+// Section is a group of entries under one Keep a Changelog heading
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Release is the changelog of one version
+type Release struct {
+	Version  string
+	Date     string
+	Sections []Section
+	// Skipped counts commits that were not Conventional Commits
+	Skipped int
+}
+
+// Build parses the commits with the convention and groups them into sections by type,
+// sorted by scope within each section. Types without a section (docs, chore, ...) are
+// only kept, under "Other", when all is set.
+func Build(commits []git.Commit, conv convention.Convention, version, date string, all bool) Release {
+	release := Release{Version: version, Date: date}
+	bySection := map[string][]Entry{}
+
+	for _, c := range commits {
+		parsed, err := conv.Parse(c.Message)
+		if err != nil {
+			release.Skipped++
+			continue
+		}
+
+		title, ok := sectionForType[parsed.Type]
+		if parsed.Type == "fix" && strings.EqualFold(parsed.Scope, "security") {
+			title = "Security"
+		}
+		if !ok {
+			if !all && !parsed.Breaking {
+				continue
+			}
+			title = "Other"
+		}
+
+		bySection[title] = append(bySection[title], Entry{
+			Hash:         c.Hash,
+			Type:         parsed.Type,
+			Scope:        parsed.Scope,
+			Description:  parsed.Description,
+			Breaking:     parsed.Breaking,
+			BreakingNote: parsed.BreakingNote,
+		})
+	}
+
+	for _, title := range sectionOrder {
+		entries := bySection[title]
+		if len(entries) == 0 {
+			continue
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Scope < entries[j].Scope })
+		release.Sections = append(release.Sections, Section{Title: title, Entries: entries})
+	}
+
+	return release
+}
+
+// Entries returns pointers to every entry, in rendering order
+func (r *Release) Entries() []*Entry {
+	var entries []*Entry
+	for i := range r.Sections {
+		for j := range r.Sections[i].Entries {
+			entries = append(entries, &r.Sections[i].Entries[j])
+		}
+	}
+	return entries
+}
+
+// Markdown renders the release as a Keep a Changelog version section.
+// Breaking changes are listed first and flagged again in their own section.
+func (r Release) Markdown() string {
+	var b strings.Builder
+
+	if r.Date != "" {
+		fmt.Fprintf(&b, "## [%s] - %s\n", r.Version, r.Date)
+	} else {
+		fmt.Fprintf(&b, "## [%s]\n", r.Version)
+	}
+
+	var breaking []string
+	for _, entry := range r.Entries() {
+		if !entry.Breaking {
+			continue
+		}
+		note := entry.BreakingNote
+		if note == "" {
+			note = entry.Description
+		}
+		breaking = append(breaking, formatLine(entry.Scope, note, entry.Hash))
+	}
+
+	if len(breaking) > 0 {
+		b.WriteString("\n### ⚠ Breaking Changes\n\n")
+		for _, line := range breaking {
+			b.WriteString(line)
+		}
+	}
+
+	for _, section := range r.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			description := entry.Description
+			if entry.Breaking {
+				description = "**BREAKING** " + description
+			}
+			b.WriteString(formatLine(entry.Scope, description, entry.Hash))
+		}
+	}
+
+	if len(r.Sections) == 0 {
+		b.WriteString("\nNo notable changes.\n")
+	}
+
+	return b.String()
+}
+
+func formatLine(scope, text, hash string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\n", " ")
+	if scope != "" {
+		text = fmt.Sprintf("**%s:** %s", scope, text)
+	}
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	return fmt.Sprintf("- %s (%s)\n", text, hash)
+}
+
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+`
+
+// Prepend inserts a version section above the newest one in an existing changelog,
+// creating the standard header when the changelog is empty
+func Prepend(existing, section string) string {
+	if strings.TrimSpace(existing) == "" {
+		return changelogHeader + "\n" + section
+	}
+
+	if i := strings.Index(existing, "\n## "); i >= 0 {
+		return existing[:i+1] + section + "\n" + existing[i+1:]
+	}
+
+	return strings.TrimRight(existing, "\n") + "\n\n" + section
+}
@@ -0,0 +1,142 @@
+package changelog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+func commits(messages ...string) []git.Commit {
+	var list []git.Commit
+	for i, message := range messages {
+		hash := strings.Repeat(string(rune('a'+i)), 40)
+		list = append(list, git.Commit{Hash: hash, Message: message})
+	}
+	return list
+}
+
+func titles(r Release) []string {
+	var list []string
+	for _, s := range r.Sections {
+		list = append(list, s.Title)
+	}
+	return list
+}
+
+func TestBuild(t *testing.T) {
+	history := commits(
+		"fix(ui): align buttons",
+		"feat(api): add search endpoint",
+		"docs: update readme",
+		"wip",
+		"fix(security): escape user input",
+		"feat(parser)!: drop legacy syntax",
+		"refactor: split config loading\n\nBREAKING CHANGE: Config.Load now returns an error",
+	)
+
+	tests := []struct {
+		name    string
+		all     bool
+		titles  []string
+		skipped int
+		entries int
+	}{
+		{"notable types only", false, []string{"Added", "Changed", "Fixed", "Security"}, 1, 5},
+		{"all types", true, []string{"Added", "Changed", "Fixed", "Security", "Other"}, 1, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := Build(history, convention.Default(), "1.0.0", "2024-01-02", tt.all)
+
+			if got := titles(release); !reflect.DeepEqual(got, tt.titles) {
+				t.Errorf("sections = %v, want %v", got, tt.titles)
+			}
+			if release.Skipped != tt.skipped {
+				t.Errorf("Skipped = %d, want %d", release.Skipped, tt.skipped)
+			}
+			if got := len(release.Entries()); got != tt.entries {
+				t.Errorf("%d entries, want %d", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestBuildSortsByScope(t *testing.T) {
+	release := Build(commits("feat(b): second", "feat: no scope", "feat(a): first"), convention.Default(), "1.0.0", "", false)
+
+	var scopes []string
+	for _, e := range release.Entries() {
+		scopes = append(scopes, e.Scope)
+	}
+	if want := []string{"", "a", "b"}; !reflect.DeepEqual(scopes, want) {
+		t.Errorf("scopes = %q, want %q", scopes, want)
+	}
+}
+
+func TestMarkdownBreakingChanges(t *testing.T) {
+	release := Build(commits(
+		"feat(parser)!: drop legacy syntax",
+		"refactor: split config loading\n\nBREAKING CHANGE: Config.Load now returns an error",
+		"fix: handle empty input",
+	), convention.Default(), "2.0.0", "2024-01-02", false)
+
+	want := `## [2.0.0] - 2024-01-02
+
+### ⚠ Breaking Changes
+
+- **parser:** drop legacy syntax (aaaaaaa)
+- Config.Load now returns an error (bbbbbbb)
+
+### Added
+
+- **parser:** **BREAKING** drop legacy syntax (aaaaaaa)
+
+### Changed
+
+- **BREAKING** split config loading (bbbbbbb)
+
+### Fixed
+
+- handle empty input (ccccccc)
+`
+	if got := release.Markdown(); got != want {
+		t.Errorf("Markdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdownEmpty(t *testing.T) {
+	release := Build(commits("chore: tidy"), convention.Default(), "1.0.1", "", false)
+	if got, want := release.Markdown(), "## [1.0.1]\n\nNo notable changes.\n"; got != want {
+		t.Errorf("Markdown() = %q, want %q", got, want)
+	}
+}
+
+func TestPrepend(t *testing.T) {
+	section := "## [1.1.0]\n\n### Added\n\n- new (aaaaaaa)\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"empty changelog", "", changelogHeader + "\n" + section},
+		{
+			"above the newest version",
+			"# Changelog\n\n## [1.0.0]\n\n- old\n",
+			"# Changelog\n\n" + section + "\n## [1.0.0]\n\n- old\n",
+		},
+		{"no versions yet", "# Changelog\n", "# Changelog\n\n" + section},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Prepend(tt.existing, section); got != tt.want {
+				t.Errorf("Prepend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/prompt"
+)
+
+type polishResponse struct {
+	Entries []string `json:"entries"`
+}
+
+// Polish asks the model to rewrite every entry's description into a user-facing note.
+// The release is left untouched if the reply cannot be matched to the entries.
+func Polish(generator *commit.Generator, release *Release, rules []string) error {
+	entries := release.Entries()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	request := make([]prompt.PolishEntry, len(entries))
+	for i, entry := range entries {
+		request[i] = prompt.PolishEntry{Type: entry.Type, Scope: entry.Scope, Subject: entry.Description}
+	}
+
+	reply, err := generator.Complete(generator.Prompt().BuildPolishMessages(request, rules))
+	if err != nil {
+		return err
+	}
+
+	var resp polishResponse
+	if err := json.Unmarshal([]byte(prompt.ExtractJSON(reply)), &resp); err != nil {
+		return fmt.Errorf("could not parse polished entries: %v", err)
+	}
+
+	if len(resp.Entries) != len(entries) {
+		return fmt.Errorf("expected %d polished entries, got %d", len(entries), len(resp.Entries))
+	}
+
+	for i, entry := range entries {
+		if note := strings.TrimSpace(resp.Entries[i]); note != "" {
+			entry.Description = note
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package commit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dacsang97/aigc/internal/cache"
+	"github.com/dacsang97/aigc/internal/config"
+)
+
+// Cache opens the response cache described by the cache settings
+func Cache(cfg config.CacheConfig) (*cache.Cache, error) {
+	dir, err := cfg.Directory()
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+	if cfg.TTL != "" {
+		if ttl, err = time.ParseDuration(cfg.TTL); err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid cache ttl %q: must be a positive duration such as 24h", cfg.TTL)
+		}
+	}
+	if cfg.MaxSize < 0 {
+		return nil, fmt.Errorf("invalid cache max_size %d: must be a number of megabytes", cfg.MaxSize)
+	}
+
+	return cache.New(dir, ttl, int64(cfg.MaxSize)<<20), nil
+}
@@ -1,38 +1,301 @@
 package commit
 
 import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/prompt"
 	"github.com/dacsang97/aigc/internal/provider"
+	"github.com/dacsang97/aigc/internal/style"
 )
 
+// recentCommitCount is how many recent subjects are made available to templates
+const recentCommitCount = 10
+
 type Generator struct {
-	provider interface {
-		Generate(changes, userMessage string, rules []string) (string, error)
-	}
+	provider   provider.Provider
+	prompt     *prompt.Generator
+	convention convention.Convention
+	language   string
+	// configured is set when the convention was chosen explicitly, so a learned style
+	// does not override it
+	configured bool
+	// tickets are the IDs extracted from the branch name, added to every message
+	tickets      []string
+	ticketConfig config.TicketConfig
+	// structured asks the model for a JSON message that is formatted in Go
+	structured bool
 }
 
 type ProviderConfig struct {
-	Provider string
-	Model    string
-	APIKey   string
-	Endpoint string
+	Provider   string
+	Model      string
+	APIKey     string
+	Endpoint   string
+	APIStyle   string
+	Generation provider.Generation
+	HTTPClient *http.Client
 }
 
 func New(config ProviderConfig) (*Generator, error) {
 	p, err := provider.NewProvider(provider.Config{
-		Provider: config.Provider,
-		Model:    config.Model,
-		APIKey:   config.APIKey,
-		Endpoint: config.Endpoint,
+		Provider:   config.Provider,
+		Model:      config.Model,
+		APIKey:     config.APIKey,
+		Endpoint:   config.Endpoint,
+		APIStyle:   config.APIStyle,
+		Generation: config.Generation,
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &Generator{
-		provider: p,
+		provider:   p,
+		prompt:     prompt.New(),
+		convention: convention.Default(),
+		language:   prompt.DefaultLanguage,
 	}, nil
 }
 
+// NewFromConfig creates a generator from the provider and convention settings of the
+// application config. root is the working tree root of the repository the generator
+// works on, recorded with token usage; it may be empty.
+func NewFromConfig(cfg config.Config, root string) (*Generator, error) {
+	c, err := convention.Get(cfg.Convention)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTicketConfig(cfg.Ticket); err != nil {
+		return nil, err
+	}
+	generation, err := Generation(cfg.Generation)
+	if err != nil {
+		return nil, err
+	}
+	client, err := HTTPClient(cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := New(ProviderConfig{
+		Provider:   cfg.Provider.Provider,
+		Model:      cfg.Provider.Model,
+		APIKey:     cfg.Provider.APIKey,
+		Endpoint:   cfg.Provider.Endpoint,
+		APIStyle:   cfg.Provider.APIStyle,
+		Generation: generation,
+		HTTPClient: client,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.Usage.Disabled {
+		store, err := UsageStore(cfg.Usage)
+		if err != nil {
+			return nil, err
+		}
+		budget, err := Budget(cfg.Usage)
+		if err != nil {
+			return nil, err
+		}
+		metered := provider.WithUsage(g.provider, store, Prices(cfg.Usage), budget, provider.Config{
+			Provider: cfg.Provider.Provider,
+			Model:    cfg.Provider.Model,
+		}, os.Stderr)
+		metered.SetRepository(root)
+		g.provider = metered
+	}
+
+	// The cache wraps the metered provider so cache hits are not recorded as requests
+	if !cfg.Cache.Disabled {
+		responses, err := Cache(cfg.Cache)
+		if err != nil {
+			return nil, err
+		}
+		g.provider = provider.WithCache(g.provider, responses, provider.Config{
+			Provider:   cfg.Provider.Provider,
+			Model:      cfg.Provider.Model,
+			Endpoint:   cfg.Provider.Endpoint,
+			APIStyle:   cfg.Provider.APIStyle,
+			Generation: generation,
+		})
+	}
+
+	g.SetConvention(c)
+	g.configured = cfg.Convention != ""
+	g.SetLanguage(cfg.Language)
+	g.ticketConfig = cfg.Ticket
+	g.structured = cfg.Generation.Structured
+	return g, nil
+}
+
 func (g *Generator) Generate(changes, userMessage string, rules []string) (string, error) {
-	return g.provider.Generate(changes, userMessage, rules)
+	messages, err := g.prompt.BuildMessages(changes, userMessage, rules)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := g.completeMessage(messages)
+	if err != nil {
+		return "", err
+	}
+	return g.AddTickets(CleanMessage(message)), nil
+}
+
+// Regenerate generates a new message after a previous one was rejected, for example
+// by a commit-msg hook. The rejected message and the reason are sent along so the
+// model can correct its own output.
+func (g *Generator) Regenerate(changes, userMessage string, rules []string, rejected, reason string) (string, error) {
+	messages, err := g.prompt.BuildMessages(changes, userMessage, rules)
+	if err != nil {
+		return "", err
+	}
+	messages = g.prompt.AppendRejection(messages, rejected, reason)
+
+	message, err := g.completeMessage(messages)
+	if err != nil {
+		return "", err
+	}
+	return g.AddTickets(CleanMessage(message)), nil
+}
+
+// Complete sends a custom message list to the provider
+func (g *Generator) Complete(messages []prompt.Message) (string, error) {
+	return g.provider.Complete(messages)
+}
+
+// CacheHits returns how many generations were served from the response cache
+func (g *Generator) CacheHits() int {
+	if cached, ok := g.provider.(*provider.CachedProvider); ok {
+		return cached.Hits()
+	}
+	return 0
+}
+
+// Prompt returns the prompt generator, configured with the active convention and style
+func (g *Generator) Prompt() *prompt.Generator {
+	return g.prompt
+}
+
+// Convention returns the convention generated messages follow
+func (g *Generator) Convention() convention.Convention {
+	return g.convention
+}
+
+// SetConvention changes the convention generated messages follow
+func (g *Generator) SetConvention(c convention.Convention) {
+	g.convention = c
+	g.prompt.SetConvention(c)
+}
+
+// SetLanguage changes the language generated messages are written in
+func (g *Generator) SetLanguage(language string) {
+	g.language = prompt.LanguageName(language)
+	g.prompt.SetLanguage(language)
+}
+
+// UseStyle makes generated messages follow a style learned from the repository's history.
+// The learned convention only applies when none was configured explicitly.
+func (g *Generator) UseStyle(profile style.Profile) {
+	if !g.configured {
+		if c, err := convention.Get(profile.Convention); err == nil {
+			g.SetConvention(c)
+		}
+	}
+	g.prompt.SetExamples(profile.Examples)
+}
+
+// LoadRepositoryContext loads prompt templates from the repository and the global
+// template directory, and makes the branch, changed files and recent commits
+// available to them. rev selects the commit being reworded; empty means staged changes.
+func (g *Generator) LoadRepositoryContext(gitClient git.Client, rev, globalTemplateDir string) error {
+	templates, err := prompt.LoadTemplates(prompt.TemplateDirs(gitClient.Root(), globalTemplateDir)...)
+	if err != nil {
+		return err
+	}
+	g.prompt.SetTemplates(templates)
+
+	files, err := gitClient.ChangedFiles(rev)
+	if err != nil {
+		return err
+	}
+
+	// The prompt may list the changed files without all of their content, so cached
+	// messages are keyed on the staged tree or the commit being described
+	if cached, ok := g.provider.(*provider.CachedProvider); ok {
+		scope, err := contentScope(gitClient, rev)
+		if err != nil {
+			return err
+		}
+		cached.SetScope(scope)
+	}
+
+	var recent []string
+	if commits, err := gitClient.RecentCommits(recentCommitCount); err == nil {
+		for _, c := range commits {
+			recent = append(recent, c.Subject)
+		}
+	}
+
+	if err := g.LoadTickets(gitClient); err != nil {
+		return err
+	}
+
+	g.prompt.SetContext(prompt.Context{
+		Files:         files,
+		Branch:        gitClient.CurrentBranch(),
+		RecentCommits: recent,
+		Tickets:       g.tickets,
+	})
+	return nil
+}
+
+// contentScope identifies the content behind rev: the staged tree when rev is empty,
+// the commit itself otherwise
+func contentScope(gitClient git.Client, rev string) (string, error) {
+	if rev == "" {
+		return gitClient.SaveIndex()
+	}
+	return gitClient.ResolveCommit(rev)
+}
+
+// LoadTickets extracts ticket IDs from the current branch name when enabled in the
+// config. They are added to every generated message by AddTickets.
+func (g *Generator) LoadTickets(gitClient git.Client) error {
+	if !g.ticketConfig.Enabled {
+		return nil
+	}
+
+	tickets, err := gitClient.BranchTickets(g.ticketConfig.Patterns)
+	if err != nil {
+		return err
+	}
+	g.tickets = tickets
+	return nil
+}
+
+// Validate returns the problems of a message according to the active convention and language
+func (g *Generator) Validate(message string) []string {
+	return convention.ValidateIn(g.convention, message, g.language)
+}
+
+// CleanMessage removes surrounding whitespace and code fences some models add to a reply
+func CleanMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if strings.HasPrefix(message, "```") && strings.HasSuffix(message, "```") {
+		message = strings.TrimPrefix(message, "```")
+		message = strings.TrimSuffix(message, "```")
+		if i := strings.Index(message, "\n"); i >= 0 && !strings.Contains(message[:i], " ") {
+			// Drop a language tag such as ```text
+			message = message[i+1:]
+		}
+	}
+	return strings.TrimSpace(message)
 }
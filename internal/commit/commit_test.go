@@ -0,0 +1,82 @@
+package commit
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dacsang97/aigc/internal/cache"
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/prompt"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+// countingProvider replies with a new message for every request it receives
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Complete(messages []prompt.Message) (string, error) {
+	p.calls++
+	return "feat: change " + strings.Repeat("a", p.calls), nil
+}
+
+func TestCachedMessageKeyedOnStagedContent(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "--quiet")
+
+	counting := &countingProvider{}
+	g := &Generator{
+		provider:   provider.WithCache(counting, cache.New(t.TempDir(), 0, 0), provider.Config{Provider: "test", Model: "test"}),
+		prompt:     prompt.New(),
+		convention: convention.Default(),
+		language:   prompt.DefaultLanguage,
+	}
+	repo, err := git.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The changes are the same each time, like a prompt that only lists the files
+	generate := func(content string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitRun(t, dir, "add", "a.txt")
+		if err := g.LoadRepositoryContext(repo, "", t.TempDir()); err != nil {
+			t.Fatal(err)
+		}
+		message, err := g.Generate("A\ta.txt", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return message
+	}
+
+	first := generate("one\n")
+	if second := generate("two\n"); second == first || counting.calls != 2 {
+		t.Errorf("changed content gave %q after %q with %d provider calls, want a cache miss", second, first, counting.calls)
+	}
+	if again := generate("two\n"); counting.calls != 2 || g.CacheHits() != 1 {
+		t.Errorf("unchanged content gave %q with %d provider calls and %d hits, want a cache hit", again, counting.calls, g.CacheHits())
+	}
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,34 @@
+package commit
+
+import (
+	"fmt"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+// Generation validates the generation settings and converts them for the provider
+func Generation(cfg config.GenerationConfig) (provider.Generation, error) {
+	if t := cfg.Temperature; t != nil && (*t < 0 || *t > 2) {
+		return provider.Generation{}, fmt.Errorf("invalid temperature %g: must be between 0 and 2", *t)
+	}
+	if p := cfg.TopP; p != nil && (*p <= 0 || *p > 1) {
+		return provider.Generation{}, fmt.Errorf("invalid top_p %g: must be greater than 0 and at most 1", *p)
+	}
+	if cfg.MaxTokens < 0 {
+		return provider.Generation{}, fmt.Errorf("invalid max_tokens %d: must be positive", cfg.MaxTokens)
+	}
+	switch cfg.ReasoningEffort {
+	case "", "minimal", "low", "medium", "high":
+	default:
+		return provider.Generation{}, fmt.Errorf("invalid reasoning_effort %q: must be minimal, low, medium or high", cfg.ReasoningEffort)
+	}
+
+	return provider.Generation{
+		Temperature:     cfg.Temperature,
+		MaxTokens:       cfg.MaxTokens,
+		TopP:            cfg.TopP,
+		Stop:            cfg.Stop,
+		ReasoningEffort: cfg.ReasoningEffort,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package commit
+
+import (
+	"path/filepath"
+
+	"github.com/dacsang97/aigc/internal/cache"
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/models"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+// ListModels returns the models offered by the configured provider. Lists are cached
+// for a day in the models directory of the cache; refresh fetches a new one.
+func ListModels(cfg config.Config, refresh bool) ([]provider.Model, error) {
+	client, err := HTTPClient(cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	p, err := provider.NewProvider(provider.Config{
+		Provider:   cfg.Provider.Provider,
+		Model:      cfg.Provider.Model,
+		APIKey:     cfg.Provider.APIKey,
+		Endpoint:   cfg.Provider.Endpoint,
+		HTTPClient: client,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := cfg.Cache.Directory()
+	if err != nil {
+		return nil, err
+	}
+	lists := cache.New(filepath.Join(dir, "models"), models.TTL, 0)
+	key := cache.Key("models", cfg.Provider.Provider, cfg.Provider.Endpoint)
+
+	return models.List(p, lists, key, refresh || cfg.Cache.Disabled)
+}
@@ -0,0 +1,52 @@
+package commit
+
+import (
+	"fmt"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// Signing modes accepted in the config
+const (
+	SignAlways = "always"
+	SignNever  = "never"
+)
+
+// Signing resolves how commits are signed from the config and the --sign, --no-sign
+// and -S flags. A key implies --sign. Disabling signing while the config requires it
+// is an error.
+func Signing(cfg config.SigningConfig, sign, noSign bool, key string) (git.Signing, error) {
+	s := git.Signing{Key: cfg.Key, Require: cfg.Require}
+
+	switch cfg.Mode {
+	case "":
+	case SignAlways:
+		s.Mode = git.SignAlways
+	case SignNever:
+		s.Mode = git.SignNever
+	default:
+		return git.Signing{}, fmt.Errorf("invalid signing mode %q, must be %s or %s", cfg.Mode, SignAlways, SignNever)
+	}
+
+	if key != "" {
+		s.Key = key
+		sign = true
+	}
+	if sign && noSign {
+		return git.Signing{}, fmt.Errorf("--sign and --no-sign cannot be used together")
+	}
+
+	switch {
+	case sign:
+		s.Mode = git.SignAlways
+	case noSign:
+		s.Mode = git.SignNever
+	}
+
+	if s.Require && s.Mode == git.SignNever {
+		return git.Signing{}, fmt.Errorf("signing is required by signing.require, it cannot be disabled")
+	}
+
+	return s, nil
+}
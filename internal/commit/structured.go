@@ -0,0 +1,127 @@
+package commit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/prompt"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+// StructuredMessage is the JSON object the model returns in structured output mode
+type StructuredMessage struct {
+	Type     string             `json:"type"`
+	Scope    string             `json:"scope"`
+	Subject  string             `json:"subject"`
+	Body     string             `json:"body"`
+	Breaking bool               `json:"breaking"`
+	Footers  []StructuredFooter `json:"footers"`
+}
+
+// StructuredFooter is a footer of a StructuredMessage
+type StructuredFooter struct {
+	Token string `json:"token"`
+	Value string `json:"value"`
+}
+
+// messageSchema describes StructuredMessage. Every property is required, as strict
+// schemas demand; optional parts are empty strings or lists.
+var messageSchema = provider.Schema{
+	Name:        "commit_message",
+	Description: "A commit message broken into its parts",
+	Schema: map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"type", "scope", "subject", "body", "breaking", "footers"},
+		"properties": map[string]any{
+			"type":     map[string]any{"type": "string", "description": "Conventional Commits type, e.g. feat or fix"},
+			"scope":    map[string]any{"type": "string", "description": "Affected area, or an empty string"},
+			"subject":  map[string]any{"type": "string", "description": "Description in one line, without type, scope or issue key"},
+			"body":     map[string]any{"type": "string", "description": "Body paragraphs, or an empty string"},
+			"breaking": map[string]any{"type": "boolean", "description": "Whether the change breaks backward compatibility"},
+			"footers": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"token", "value"},
+					"properties": map[string]any{
+						"token": map[string]any{"type": "string"},
+						"value": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+	},
+}
+
+// completeMessage sends the prompt and returns the raw commit message. In structured
+// mode the model returns a StructuredMessage that is formatted with the convention;
+// providers without structured output, and models that answer in plain text anyway,
+// fall back to the text response.
+func (g *Generator) completeMessage(messages []prompt.Message) (string, error) {
+	if !g.structured {
+		return g.provider.Complete(messages)
+	}
+
+	response, err := provider.CompleteJSON(g.provider, g.prompt.AppendStructured(messages), messageSchema)
+	if errors.Is(err, provider.ErrStructuredUnsupported) {
+		return g.provider.Complete(messages)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	response = CleanMessage(response)
+	if !strings.HasPrefix(response, "{") {
+		return response, nil
+	}
+	m, err := parseStructured(response)
+	if err != nil {
+		return "", fmt.Errorf("the model returned an invalid structured message: %v", err)
+	}
+	return g.convention.Format(m), nil
+}
+
+// parseStructured decodes a StructuredMessage and checks what the schema cannot
+// express
+func parseStructured(response string) (convention.Message, error) {
+	var s StructuredMessage
+	if err := json.Unmarshal([]byte(response), &s); err != nil {
+		return convention.Message{}, err
+	}
+
+	subject := strings.TrimSpace(s.Subject)
+	if subject == "" {
+		return convention.Message{}, fmt.Errorf("subject is empty")
+	}
+	if strings.Contains(subject, "\n") {
+		return convention.Message{}, fmt.Errorf("subject spans several lines")
+	}
+
+	m := convention.Message{
+		Type:        strings.ToLower(strings.TrimSpace(s.Type)),
+		Scope:       strings.TrimSpace(s.Scope),
+		Description: subject,
+		Body:        strings.TrimSpace(s.Body),
+		Breaking:    s.Breaking,
+	}
+	if m.Type == "" {
+		m.Type = "chore"
+	}
+	for _, f := range s.Footers {
+		token, value := strings.TrimSpace(f.Token), strings.TrimSpace(f.Value)
+		if token == "" || value == "" {
+			continue
+		}
+		if token == "BREAKING CHANGE" || token == "BREAKING-CHANGE" {
+			m.Breaking = true
+			m.BreakingNote = value
+		}
+		m.Footers = append(m.Footers, convention.Footer{Token: token, Value: value})
+	}
+	return m, nil
+}
@@ -0,0 +1,117 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/convention"
+)
+
+// Ticket placements
+const (
+	TicketFooter = "footer"
+	TicketPrefix = "prefix"
+	TicketNone   = "none"
+)
+
+// defaultTicketFooter is the footer token used when none is configured
+const defaultTicketFooter = "Refs"
+
+// AddTickets makes sure every ticket extracted from the branch is referenced in the
+// message, whatever the model returned. Tickets already present in the expected place
+// are left alone, so applying it twice changes nothing.
+func (g *Generator) AddTickets(message string) string {
+	if len(g.tickets) == 0 {
+		return message
+	}
+
+	switch g.ticketConfig.Placement {
+	case TicketNone:
+		return message
+	case TicketPrefix:
+		return prefixTickets(message, g.tickets, g.convention)
+	default:
+		return footerTickets(message, g.tickets, g.ticketConfig.Footer)
+	}
+}
+
+// footerTickets appends a "<token>: <ticket>" footer for every ticket not referenced
+// in the footers yet, extending an existing footer block rather than starting a new one
+func footerTickets(message string, tickets []string, token string) string {
+	if token == "" {
+		token = defaultTicketFooter
+	}
+
+	message = strings.TrimSpace(message)
+	paragraphs := strings.Split(message, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+	hasFooters := len(paragraphs) > 1 && isFooterBlock(last)
+
+	var lines []string
+	for _, ticket := range tickets {
+		if hasFooters && strings.Contains(last, ticket) {
+			continue
+		}
+		lines = append(lines, token+": "+ticket)
+	}
+	if len(lines) == 0 {
+		return message
+	}
+
+	if hasFooters {
+		return message + "\n" + strings.Join(lines, "\n")
+	}
+	return message + "\n\n" + strings.Join(lines, "\n")
+}
+
+// isFooterBlock reports whether every line of a paragraph looks like a git trailer
+func isFooterBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		token, _, ok := strings.Cut(line, ":")
+		if !ok || token == "" || strings.ContainsAny(strings.TrimPrefix(token, "BREAKING CHANGE"), " \t") {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixTickets puts the tickets at the start of the description. Jira-prefixed
+// messages get the first ticket as their issue key instead, replacing any key the
+// model made up.
+func prefixTickets(message string, tickets []string, c convention.Convention) string {
+	m, err := c.Parse(message)
+	if err != nil {
+		// The message does not follow the convention; treat its subject as the description
+		c, _ = convention.Get("plain")
+		if m, err = c.Parse(message); err != nil {
+			return message
+		}
+	}
+
+	if c.Name() == "jira" {
+		m.Ticket = tickets[0]
+		tickets = tickets[1:]
+	}
+
+	var missing []string
+	for _, ticket := range tickets {
+		if !strings.Contains(m.Description, ticket) {
+			missing = append(missing, ticket)
+		}
+	}
+	if len(missing) > 0 {
+		m.Description = strings.Join(missing, " ") + " " + m.Description
+	}
+
+	return c.Format(m)
+}
+
+// validateTicketConfig checks the ticket placement is one AIGC knows how to apply
+func validateTicketConfig(cfg config.TicketConfig) error {
+	switch cfg.Placement {
+	case "", TicketFooter, TicketPrefix, TicketNone:
+		return nil
+	}
+	return fmt.Errorf("invalid ticket placement %q, must be one of %s, %s or %s", cfg.Placement, TicketFooter, TicketPrefix, TicketNone)
+}
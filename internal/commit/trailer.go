@@ -0,0 +1,55 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// Trailers resolves the trailers to append to a generated message: the custom trailers
+// from the config, a Co-authored-by trailer per co-author and, when signOff is set,
+// the user's Signed-off-by. Co-authors are aliases from the team roster or literal
+// "Name <email>" identities.
+func Trailers(gitClient git.Client, cfg config.TrailerConfig, coAuthors []string, signOff bool) ([]git.Trailer, error) {
+	var trailers []git.Trailer
+
+	for _, custom := range cfg.Custom {
+		token, value, ok := strings.Cut(custom, ":")
+		if !ok || strings.TrimSpace(token) == "" || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("invalid trailer %q, expected \"Token: value\"", custom)
+		}
+		trailers = append(trailers, git.Trailer{Token: strings.TrimSpace(token), Value: strings.TrimSpace(value)})
+	}
+
+	for _, coAuthor := range coAuthors {
+		identity, err := resolveCoAuthor(cfg.Team, coAuthor)
+		if err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, git.Trailer{Token: "Co-authored-by", Value: identity})
+	}
+
+	if signOff {
+		trailer, err := gitClient.SignOff()
+		if err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, trailer)
+	}
+
+	return trailers, nil
+}
+
+// resolveCoAuthor looks an alias up in the team roster, accepting "Name <email>" as is
+func resolveCoAuthor(team map[string]string, coAuthor string) (string, error) {
+	coAuthor = strings.TrimSpace(coAuthor)
+	if identity, ok := team[coAuthor]; ok {
+		return identity, nil
+	}
+	if strings.Contains(coAuthor, "<") && strings.HasSuffix(coAuthor, ">") {
+		return coAuthor, nil
+	}
+	return "", fmt.Errorf("unknown co-author %q; add it to trailers.team in the config or pass \"Name <email>\"", coAuthor)
+}
@@ -0,0 +1,20 @@
+package commit
+
+import (
+	"net/http"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/transport"
+)
+
+// HTTPClient builds the client provider requests are sent with from the http settings
+func HTTPClient(cfg config.HTTPConfig) (*http.Client, error) {
+	return transport.NewClient(transport.Config{
+		Headers:            cfg.Headers,
+		Proxy:              cfg.Proxy,
+		CACert:             cfg.CACert,
+		ClientCert:         cfg.ClientCert,
+		ClientKey:          cfg.ClientKey,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	})
+}
@@ -0,0 +1,37 @@
+package commit
+
+import (
+	"fmt"
+
+	"github.com/dacsang97/aigc/internal/config"
+	"github.com/dacsang97/aigc/internal/usage"
+)
+
+// UsageStore opens the store requests are recorded in
+func UsageStore(cfg config.UsageConfig) (*usage.Store, error) {
+	path, err := cfg.Path()
+	if err != nil {
+		return nil, err
+	}
+	return usage.NewStore(path), nil
+}
+
+// Prices returns the built-in price table with the configured prices applied
+func Prices(cfg config.UsageConfig) usage.PriceTable {
+	overrides := make(map[string]usage.Price, len(cfg.Prices))
+	for model, price := range cfg.Prices {
+		overrides[model] = usage.Price{Input: price.Input, Output: price.Output}
+	}
+	return usage.NewPriceTable(overrides)
+}
+
+// Budget returns the configured monthly budget
+func Budget(cfg config.UsageConfig) (usage.Budget, error) {
+	if err := usage.ValidateAction(cfg.BudgetAction); err != nil {
+		return usage.Budget{}, err
+	}
+	if cfg.Budget < 0 {
+		return usage.Budget{}, fmt.Errorf("invalid usage budget %.2f: must not be negative", cfg.Budget)
+	}
+	return usage.Budget{Monthly: cfg.Budget, Action: cfg.BudgetAction}, nil
+}
@@ -11,20 +11,141 @@ import (
 
 type Config struct {
 	Provider struct {
-		Provider string `yaml:"provider"` // "openai" or "openrouter" or "custom"
-		Model    string `yaml:"model"`    // The model to use
-		APIKey   string `yaml:"api_key"`  // The API key for the provider
-		Endpoint string `yaml:"endpoint"` // Custom API endpoint URL (optional)
+		Provider string `yaml:"provider"`  // "openai" or "openrouter" or "custom"
+		Model    string `yaml:"model"`     // The model to use
+		APIKey   string `yaml:"api_key"`   // The API key for the provider
+		Endpoint string `yaml:"endpoint"`  // Custom API endpoint URL (optional)
+		APIStyle string `yaml:"api_style"` // chat (default) or responses, for openai and custom providers
 	} `yaml:"provider"`
-	Debug bool   `yaml:"debug"`
-	Rules string `yaml:"rules"`
+	Debug      bool             `yaml:"debug"`
+	Rules      string           `yaml:"rules"`
+	Convention string           `yaml:"convention"` // Commit convention (conventional, angular, gitmoji, jira or plain)
+	Language   string           `yaml:"language"`   // Language of generated messages (default English)
+	Style      StyleConfig      `yaml:"style"`
+	Ticket     TicketConfig     `yaml:"ticket"`
+	Trailers   TrailerConfig    `yaml:"trailers"`
+	Signing    SigningConfig    `yaml:"signing"`
+	Git        GitConfig        `yaml:"git"`
+	Hooks      HookConfig       `yaml:"hooks"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Usage      UsageConfig      `yaml:"usage"`
+	Generation GenerationConfig `yaml:"generation"`
+	HTTP       HTTPConfig       `yaml:"http"`
+}
+
+// GenerationConfig holds the sampling settings sent to the provider. Unset values
+// leave the provider's default in place.
+type GenerationConfig struct {
+	Temperature     *float64 `yaml:"temperature"`      // Sampling temperature, 0 to 2
+	MaxTokens       int      `yaml:"max_tokens"`       // Maximum tokens in the response
+	TopP            *float64 `yaml:"top_p"`            // Nucleus sampling probability mass, 0 to 1
+	Stop            []string `yaml:"stop"`             // Sequences that end the response
+	ReasoningEffort string   `yaml:"reasoning_effort"` // minimal, low, medium or high, for reasoning models
+	Structured      bool     `yaml:"structured"`       // Ask for commit messages as JSON and format them in Go
+}
+
+// HTTPConfig controls how requests reach the provider
+type HTTPConfig struct {
+	Headers            map[string]string `yaml:"headers"`              // Added to every request; values may reference environment variables
+	Proxy              string            `yaml:"proxy"`                // Proxy URL (default HTTPS_PROXY)
+	CACert             string            `yaml:"ca_cert"`              // PEM bundle trusted in addition to the system roots
+	ClientCert         string            `yaml:"client_cert"`          // PEM client certificate for mutual TLS
+	ClientKey          string            `yaml:"client_key"`           // PEM key of the client certificate
+	InsecureSkipVerify bool              `yaml:"insecure_skip_verify"` // Skip certificate verification, for local testing only
+}
+
+// StyleConfig controls learning the commit style from the repository's history
+type StyleConfig struct {
+	Learn    bool `yaml:"learn"`    // Use recent commits as few-shot examples and detect their convention
+	Sample   int  `yaml:"sample"`   // Number of recent commits to inspect (default 50)
+	Examples int  `yaml:"examples"` // Number of examples to include in the prompt (default 3)
+}
+
+// TicketConfig controls referencing the ticket IDs found in the branch name
+type TicketConfig struct {
+	Enabled   bool     `yaml:"enabled"`   // Extract ticket IDs from the current branch
+	Patterns  []string `yaml:"patterns"`  // Regexes matched against the branch name (default Jira-style keys)
+	Placement string   `yaml:"placement"` // Where to add them: footer (default), prefix or none
+	Footer    string   `yaml:"footer"`    // Footer token (default Refs)
+}
+
+// GitConfig controls how aigc talks to git
+type GitConfig struct {
+	Backend string `yaml:"backend"` // exec (default) runs the git binary, go uses go-git and needs no git installed
+}
+
+// HookConfig controls how rejections by git hooks are handled
+type HookConfig struct {
+	Retries *int `yaml:"retries"` // Times a message rejected by the commit-msg hook is regenerated (default 2, 0 fails at once)
+}
+
+// CacheConfig controls the cache of provider responses
+type CacheConfig struct {
+	Disabled bool   `yaml:"disabled"` // Always call the provider
+	Dir      string `yaml:"dir"`      // Where responses are stored (default ~/.aigc/cache)
+	TTL      string `yaml:"ttl"`      // How long a response is reused, e.g. 24h (default 168h)
+	MaxSize  int    `yaml:"max_size"` // Size limit in megabytes (default 50)
+}
+
+// Directory returns the configured cache directory or the default one
+func (c CacheConfig) Directory() (string, error) {
+	if c.Dir != "" {
+		return c.Dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aigc", "cache"), nil
+}
+
+// UsageConfig controls tracking the tokens and cost of provider requests
+type UsageConfig struct {
+	Disabled     bool                   `yaml:"disabled"`      // Do not record requests
+	File         string                 `yaml:"file"`          // Where requests are recorded (default ~/.aigc/usage.jsonl)
+	Budget       float64                `yaml:"budget"`        // Monthly budget in US dollars (0 for none)
+	BudgetAction string                 `yaml:"budget_action"` // What to do once the budget is spent: warn (default) or block
+	Prices       map[string]PriceConfig `yaml:"prices"`        // Prices of models missing from, or priced differently than, the built-in table
+}
+
+// PriceConfig is the price of a model in US dollars per million tokens
+type PriceConfig struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// Path returns the configured usage file or the default one
+func (c UsageConfig) Path() (string, error) {
+	if c.File != "" {
+		return c.File, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aigc", "usage.jsonl"), nil
+}
+
+// SigningConfig controls signing of the commits aigc creates
+type SigningConfig struct {
+	Mode    string `yaml:"mode"`    // always or never; empty leaves it to git's commit.gpgsign
+	Key     string `yaml:"key"`     // Key passed to git commit -S, overriding user.signingkey
+	Require bool   `yaml:"require"` // Fail when a new commit is not signed
+}
+
+// TrailerConfig controls the trailers appended to generated commit messages
+type TrailerConfig struct {
+	SignOff bool              `yaml:"sign_off"` // Add Signed-off-by from user.name and user.email
+	Team    map[string]string `yaml:"team"`     // Co-author aliases, e.g. alice: "Alice <alice@example.com>"
+	Custom  []string          `yaml:"custom"`   // Trailers added to every commit, e.g. "Reviewed-by: Bob <bob@example.com>"
 }
 
 type Manager struct {
-	Config     Config
-	ConfigDir  string
-	ConfigPath string
-	LogDir     string
+	Config      Config
+	ConfigDir   string
+	ConfigPath  string
+	LogDir      string
+	TemplateDir string
 }
 
 func NewManager() (*Manager, error) {
@@ -36,11 +157,13 @@ func NewManager() (*Manager, error) {
 	configDir := filepath.Join(home, ".aigc")
 	configPath := filepath.Join(configDir, "config.yaml")
 	logDir := filepath.Join(configDir, "log")
+	templateDir := filepath.Join(configDir, "templates")
 
 	return &Manager{
-		ConfigDir:  configDir,
-		ConfigPath: configPath,
-		LogDir:     logDir,
+		ConfigDir:   configDir,
+		ConfigPath:  configPath,
+		LogDir:      logDir,
+		TemplateDir: templateDir,
 	}, nil
 }
 
@@ -59,6 +182,7 @@ func (m *Manager) Load() error {
 					Model    string `yaml:"model"`
 					APIKey   string `yaml:"api_key"`
 					Endpoint string `yaml:"endpoint"`
+					APIStyle string `yaml:"api_style"`
 				}{
 					Provider: "openrouter",
 					Model:    "google/gemini-flash-1.5-8b",
@@ -70,9 +194,60 @@ func (m *Manager) Load() error {
 		return err
 	}
 
+	m.Config = Config{}
 	return yaml.Unmarshal(data, &m.Config)
 }
 
+// LocalConfig holds the settings a repository may override in .aigc.yaml. Provider,
+// HTTP, signing, usage and cache settings are deliberately absent: a cloned repository
+// must not be able to send the user's API key elsewhere, weaken TLS or write to
+// arbitrary paths.
+type LocalConfig struct {
+	Rules      string           `yaml:"rules"`
+	Convention string           `yaml:"convention"`
+	Language   string           `yaml:"language"`
+	Style      StyleConfig      `yaml:"style"`
+	Ticket     TicketConfig     `yaml:"ticket"`
+	Trailers   TrailerConfig    `yaml:"trailers"`
+	Generation GenerationConfig `yaml:"generation"`
+}
+
+// LoadLocalConfig overlays the repository-level settings of the .aigc.yaml file at the
+// root of the repository. Only the keys present in the file override the global
+// configuration; keys outside LocalConfig are ignored.
+func (m *Manager) LoadLocalConfig(repoRoot string) error {
+	path := filepath.Join(repoRoot, ".aigc.yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	local := LocalConfig{
+		Rules:      m.Config.Rules,
+		Convention: m.Config.Convention,
+		Language:   m.Config.Language,
+		Style:      m.Config.Style,
+		Ticket:     m.Config.Ticket,
+		Trailers:   m.Config.Trailers,
+		Generation: m.Config.Generation,
+	}
+	if err := yaml.Unmarshal(data, &local); err != nil {
+		return fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	m.Config.Rules = local.Rules
+	m.Config.Convention = local.Convention
+	m.Config.Language = local.Language
+	m.Config.Style = local.Style
+	m.Config.Ticket = local.Ticket
+	m.Config.Trailers = local.Trailers
+	m.Config.Generation = local.Generation
+	return nil
+}
+
 func (m *Manager) Save() error {
 	data, err := yaml.Marshal(m.Config)
 	if err != nil {
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLocalConfig(t *testing.T) {
+	root := t.TempDir()
+	local := "convention: gitmoji\nlanguage: fr\ngit:\n  backend: go-git\n"
+	if err := os.WriteFile(filepath.Join(root, ".aigc.yaml"), []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{Config: Config{Convention: "conventional", Rules: "keep it short", Git: GitConfig{Backend: "exec"}}}
+	if err := m.LoadLocalConfig(root); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Config.Convention != "gitmoji" || m.Config.Language != "fr" {
+		t.Errorf("convention, language = %q, %q, want the repository's gitmoji, fr", m.Config.Convention, m.Config.Language)
+	}
+	if m.Config.Rules != "keep it short" {
+		t.Errorf("Rules = %q, want the global rules kept", m.Config.Rules)
+	}
+	if m.Config.Git.Backend != "exec" {
+		t.Errorf("Git.Backend = %q, want keys outside LocalConfig ignored", m.Config.Git.Backend)
+	}
+}
+
+func TestLoadLocalConfigMissing(t *testing.T) {
+	m := &Manager{Config: Config{Convention: "conventional"}}
+	if err := m.LoadLocalConfig(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if m.Config.Convention != "conventional" {
+		t.Errorf("Convention = %q, want the global value", m.Config.Convention)
+	}
+}
+
+func TestLoadLocalConfigInvalid(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".aigc.yaml"), []byte("convention: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&Manager{}).LoadLocalConfig(root); err == nil {
+		t.Error("LoadLocalConfig() succeeded, want an error for invalid YAML")
+	}
+}
@@ -0,0 +1,35 @@
+package convention
+
+const angularPrompt = `Generate a commit message following the Angular commit message guidelines:
+
+<type>(<scope>): <subject>
+
+<body>
+
+<footer>
+
+Rules:
+1. Type MUST be one of:
+   - build: changes to the build system or external dependencies
+   - ci: changes to CI configuration files and scripts
+   - docs: documentation only changes
+   - feat: a new feature
+   - fix: a bug fix
+   - perf: a code change that improves performance
+   - refactor: a code change that neither fixes a bug nor adds a feature
+   - test: adding missing tests or correcting existing tests
+
+2. Scope should name the affected package or component (e.g., fix(router))
+3. Subject must be in imperative, present tense, start with a lowercase letter and have no period at the end
+4. Body should explain the motivation for the change and contrast it with the previous behavior
+5. Breaking changes MUST start the footer with BREAKING CHANGE: followed by a description and migration notes
+6. Do not use ! in the header`
+
+func init() {
+	register(conventional{
+		name:      "angular",
+		prompt:    angularPrompt,
+		types:     []string{"build", "ci", "docs", "feat", "fix", "perf", "refactor", "test"},
+		lowercase: true,
+	})
+}
@@ -0,0 +1,203 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxSubjectLength is the longest subject line any built-in convention accepts
+const maxSubjectLength = 100
+
+// Footer is a "Token: value" trailer of a commit message
+type Footer struct {
+	Token string
+	Value string
+}
+
+// Message is a commit message broken into the parts conventions care about. Type
+// always uses the Conventional Commits vocabulary (feat, fix, ...), whatever the
+// convention, so changelog and release logic work the same for all of them.
+type Message struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Footers     []Footer
+	// Breaking is set by a "!" in the header, a BREAKING CHANGE footer or a 💥 gitmoji
+	Breaking bool
+	// BreakingNote is the text of the BREAKING CHANGE footer, if any
+	BreakingNote string
+	// Ticket is the issue key of Jira-prefixed subjects
+	Ticket string
+}
+
+// Convention describes one commit message format end to end
+type Convention interface {
+	// Name is the identifier used in config, e.g. "conventional"
+	Name() string
+	// Prompt describes the format to the model
+	Prompt() string
+	// Parse splits a message into its parts, failing if it does not follow the convention
+	Parse(message string) (Message, error)
+	// Validate returns every problem found in the message, or nil if it is valid
+	Validate(message string) []string
+	// Format renders a message in the convention's format
+	Format(m Message) string
+}
+
+var registry = map[string]Convention{}
+
+func register(c Convention) {
+	registry[c.Name()] = c
+}
+
+// Default returns the convention used when none is configured
+func Default() Convention {
+	return registry["conventional"]
+}
+
+// Get returns the convention with the given name; an empty name is the default
+func Get(name string) (Convention, error) {
+	if name == "" {
+		return Default(), nil
+	}
+
+	c, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown convention: %s. Must be one of: %s", name, strings.Join(Names(), ", "))
+	}
+	return c, nil
+}
+
+// Names returns the names of all built-in conventions
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Detect returns the convention a single subject line follows. Stricter formats are
+// tried first; Angular is never detected since it shares its syntax with Conventional.
+func Detect(subject string) Convention {
+	for _, name := range []string{"gitmoji", "jira", "conventional"} {
+		if _, err := registry[name].Parse(subject); err == nil {
+			return registry[name]
+		}
+	}
+	return registry["plain"]
+}
+
+var (
+	footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[\w-]+)(?:: | #)(.*)$`)
+	verbTypes     = []struct {
+		pattern *regexp.Regexp
+		typ     string
+	}{
+		{regexp.MustCompile(`(?i)^(add|adds|added|implement|introduce|support|allow|enable|create)\b`), "feat"},
+		{regexp.MustCompile(`(?i)^(fix|fixes|fixed|resolve|correct|repair|prevent|handle)\b`), "fix"},
+		{regexp.MustCompile(`(?i)^(optimi[sz]e|speed up|improve performance)\b`), "perf"},
+		{regexp.MustCompile(`(?i)^(refactor|extract|rename|move|simplify|restructure|clean up)\b`), "refactor"},
+		{regexp.MustCompile(`(?i)^(document|docs)\b`), "docs"},
+		{regexp.MustCompile(`(?i)^(test|tests)\b`), "test"},
+		{regexp.MustCompile(`(?i)^(remove|delete|drop)\b`), "remove"},
+		{regexp.MustCompile(`(?i)^deprecate\b`), "deprecate"},
+		{regexp.MustCompile(`(?i)^revert\b`), "revert"},
+		{regexp.MustCompile(`(?i)^(bump|upgrade|update dependencies)\b`), "chore"},
+	}
+)
+
+// inferType guesses a Conventional Commit type from the leading verb of a free-form subject
+func inferType(subject string) string {
+	for _, v := range verbTypes {
+		if v.pattern.MatchString(subject) {
+			return v.typ
+		}
+	}
+	return ""
+}
+
+// splitMessage separates the subject line from the rest of the message
+func splitMessage(message string) (string, string) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	subject, rest, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(subject), strings.TrimSpace(rest)
+}
+
+// splitBody separates trailing footers from the body and records breaking changes
+func splitBody(rest string, m *Message) {
+	paragraphs := strings.Split(rest, "\n\n")
+	if len(paragraphs) > 0 {
+		if footers, ok := parseFooters(paragraphs[len(paragraphs)-1]); ok {
+			m.Footers = footers
+			paragraphs = paragraphs[:len(paragraphs)-1]
+		}
+	}
+	m.Body = strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+
+	for _, f := range m.Footers {
+		if f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE" {
+			m.Breaking = true
+			m.BreakingNote = f.Value
+		}
+	}
+}
+
+// parseFooters parses a paragraph as footers; continuation lines are folded into the
+// previous footer's value
+func parseFooters(paragraph string) ([]Footer, bool) {
+	var footers []Footer
+	for _, line := range strings.Split(paragraph, "\n") {
+		if match := footerPattern.FindStringSubmatch(line); match != nil {
+			footers = append(footers, Footer{Token: match[1], Value: strings.TrimSpace(match[2])})
+			continue
+		}
+		if len(footers) == 0 {
+			return nil, false
+		}
+		last := &footers[len(footers)-1]
+		last.Value = strings.TrimSpace(last.Value + "\n" + line)
+	}
+	return footers, len(footers) > 0
+}
+
+// joinMessage renders a subject, body and footers separated by empty lines
+func joinMessage(subject string, m Message) string {
+	parts := []string{subject}
+	if m.Body != "" {
+		parts = append(parts, m.Body)
+	}
+	if len(m.Footers) > 0 {
+		lines := make([]string, len(m.Footers))
+		for i, f := range m.Footers {
+			lines[i] = f.Token + ": " + f.Value
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// validateShape checks the rules shared by every convention
+func validateShape(message string) []string {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return []string{"message is empty"}
+	}
+
+	var problems []string
+	subject, rest, hasRest := strings.Cut(message, "\n")
+	if len([]rune(subject)) > maxSubjectLength {
+		problems = append(problems, fmt.Sprintf("subject is longer than %d characters", maxSubjectLength))
+	}
+	if hasRest && !strings.HasPrefix(rest, "\n") {
+		problems = append(problems, "subject must be followed by an empty line")
+	}
+	if strings.HasPrefix(message, "```") {
+		problems = append(problems, "message is wrapped in a code block")
+	}
+	return problems
+}
@@ -0,0 +1,259 @@
+package convention
+
+import (
+	"reflect"
+	"testing"
+)
+
+func mustGet(t *testing.T, name string) Convention {
+	t.Helper()
+	c, err := Get(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		convention string
+		message    string
+		want       Message
+	}{
+		{
+			"conventional", "feat(api): add search endpoint",
+			Message{Type: "feat", Scope: "api", Description: "add search endpoint"},
+		},
+		{
+			"conventional", "Fix: handle nil config\n\nThe loader crashed on empty files.\n\nRefs #123",
+			Message{Type: "fix", Description: "handle nil config", Body: "The loader crashed on empty files.", Footers: []Footer{{"Refs", "123"}}},
+		},
+		{
+			"conventional", "refactor!: drop the v1 client",
+			Message{Type: "refactor", Description: "drop the v1 client", Breaking: true},
+		},
+		{
+			"angular", "fix(router): keep query params",
+			Message{Type: "fix", Scope: "router", Description: "keep query params"},
+		},
+		{
+			"gitmoji", "✨ (parser): Add heredoc support",
+			Message{Type: "feat", Scope: "parser", Description: "Add heredoc support"},
+		},
+		{
+			"gitmoji", ":bug: Fix crash on empty input",
+			Message{Type: "fix", Description: "Fix crash on empty input"},
+		},
+		{
+			"gitmoji", "💥 Remove the legacy API",
+			Message{Type: "feat", Description: "Remove the legacy API", Breaking: true},
+		},
+		{
+			"jira", "PROJ-123: Add login page",
+			Message{Type: "feat", Description: "Add login page", Ticket: "PROJ-123"},
+		},
+		{
+			"jira", "[OPS-7] Fix deploy script",
+			Message{Type: "fix", Description: "Fix deploy script", Ticket: "OPS-7"},
+		},
+		{
+			"plain", "Simplify the retry loop\n\nUse a ticker instead of sleeping.",
+			Message{Type: "refactor", Description: "Simplify the retry loop", Body: "Use a ticker instead of sleeping."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.convention+"/"+tt.message, func(t *testing.T) {
+			got, err := mustGet(t, tt.convention).Parse(tt.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		convention string
+		message    string
+	}{
+		{"conventional", "add search endpoint"},
+		{"conventional", "feat add search endpoint"},
+		{"angular", "Update readme"},
+		{"gitmoji", "feat: add search endpoint"},
+		{"jira", "proj-123: add login page"},
+		{"jira", "Add login page"},
+		{"plain", "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.convention+"/"+tt.message, func(t *testing.T) {
+			if m, err := mustGet(t, tt.convention).Parse(tt.message); err == nil {
+				t.Errorf("Parse() = %+v, want an error", m)
+			}
+		})
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	tests := []struct {
+		convention string
+		message    string
+	}{
+		{"conventional", "feat(api): add search endpoint"},
+		{"conventional", "fix: handle nil config\n\nThe loader crashed on empty files.\n\nReviewed-by: Jane Doe"},
+		{"conventional", "feat(parser)!: drop legacy syntax\n\nBREAKING CHANGE: the old syntax is rejected"},
+		{"angular", "fix(router): keep query params\n\nBREAKING CHANGE: params are no longer decoded"},
+		{"gitmoji", "✨ (parser): Add heredoc support"},
+		{"gitmoji", "💥 Remove the legacy API\n\nUse the v2 client instead."},
+		{"jira", "PROJ-123: Add login page\n\nCo-authored-by: Jane Doe <jane@example.com>"},
+		{"plain", "Simplify the retry loop\n\nUse a ticker instead of sleeping."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.convention+"/"+tt.message, func(t *testing.T) {
+			c := mustGet(t, tt.convention)
+			m, err := c.Parse(tt.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Format(m); got != tt.message {
+				t.Errorf("Format(Parse()) = %q, want %q", got, tt.message)
+			}
+			if problems := c.Validate(tt.message); len(problems) > 0 {
+				t.Errorf("Validate() = %v, want no problems", problems)
+			}
+		})
+	}
+}
+
+func TestBreakingFooters(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		breaking bool
+		note     string
+		footers  []Footer
+	}{
+		{
+			"breaking change footer",
+			"feat: add v2 client\n\nBREAKING CHANGE: v1 endpoints are removed",
+			true, "v1 endpoints are removed",
+			[]Footer{{"BREAKING CHANGE", "v1 endpoints are removed"}},
+		},
+		{
+			"hyphenated token",
+			"feat: add v2 client\n\nBREAKING-CHANGE: v1 endpoints are removed",
+			true, "v1 endpoints are removed",
+			[]Footer{{"BREAKING-CHANGE", "v1 endpoints are removed"}},
+		},
+		{
+			"multi-line note among other footers",
+			"fix: rename options\n\nBody text.\n\nBREAKING CHANGE: Options.Dir is now Options.Root\n  and must be absolute\nRefs #42",
+			true, "Options.Dir is now Options.Root\n  and must be absolute",
+			[]Footer{{"BREAKING CHANGE", "Options.Dir is now Options.Root\n  and must be absolute"}, {"Refs", "42"}},
+		},
+		{
+			"bang and footer",
+			"feat!: add v2 client\n\nBREAKING CHANGE: v1 endpoints are removed",
+			true, "v1 endpoints are removed",
+			[]Footer{{"BREAKING CHANGE", "v1 endpoints are removed"}},
+		},
+		{
+			"breaking change in the body is not a footer",
+			"fix: rename options\n\nBREAKING CHANGE: mentioned here\nbut this paragraph is prose.\n\nThe end.",
+			false, "", nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Default().Parse(tt.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Breaking != tt.breaking || m.BreakingNote != tt.note {
+				t.Errorf("Breaking = %v, note %q, want %v, %q", m.Breaking, m.BreakingNote, tt.breaking, tt.note)
+			}
+			if !reflect.DeepEqual(m.Footers, tt.footers) {
+				t.Errorf("Footers = %+v, want %+v", m.Footers, tt.footers)
+			}
+		})
+	}
+}
+
+func TestFormatBreaking(t *testing.T) {
+	m := Message{Type: "feat", Scope: "api", Description: "remove v1 endpoints", Breaking: true}
+
+	tests := []struct {
+		convention string
+		want       string
+	}{
+		{"conventional", "feat(api)!: remove v1 endpoints"},
+		{"angular", "feat(api): remove v1 endpoints\n\nBREAKING CHANGE: remove v1 endpoints"},
+		{"gitmoji", "💥 (api): remove v1 endpoints"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.convention, func(t *testing.T) {
+			if got := mustGet(t, tt.convention).Format(m); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		convention string
+		message    string
+		problems   int
+	}{
+		{"conventional", "feat: add flag", 0},
+		{"conventional", "feature: add flag", 1},
+		{"conventional", "feat: add flag.", 1},
+		{"conventional", "feat: add flag\nno blank line", 1},
+		{"angular", "feat!: add flag", 1},
+		{"angular", "feat: Add flag", 1},
+		{"angular", "feat: Écrire le cache", 1},
+		{"angular", "feat: écrire le cache", 0},
+		{"angular", "feat: 添加缓存", 0},
+		{"angular", "chore: tidy", 1},
+		{"gitmoji", "✨ Add flag", 0},
+		{"gitmoji", "Add flag", 1},
+		{"jira", "PROJ-1: Add flag.", 1},
+		{"plain", "Add flag", 0},
+		{"plain", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.convention+"/"+tt.message, func(t *testing.T) {
+			if problems := mustGet(t, tt.convention).Validate(tt.message); len(problems) != tt.problems {
+				t.Errorf("Validate() = %q, want %d problem(s)", problems, tt.problems)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{"feat(api): add flag", "conventional"},
+		{"✨ Add flag", "gitmoji"},
+		{"PROJ-9: Add flag", "jira"},
+		{"Add flag", "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			if got := Detect(tt.subject).Name(); got != tt.want {
+				t.Errorf("Detect() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
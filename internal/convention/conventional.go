@@ -0,0 +1,133 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var headerPattern = regexp.MustCompile(`^(\w+)(?:\(([^()]*)\))?(!)?: (.+)$`)
+
+const conventionalPrompt = `Generate a commit message following the Conventional Commits standard:
+
+<type>[optional scope]: <description>
+
+[optional body]
+
+[optional footer]
+
+Rules:
+1. Type MUST be one of:
+   - feat: new feature (correlates with MINOR version)
+   - fix: bug fix (correlates with PATCH version)
+   - docs: documentation changes
+   - style: formatting, missing semi colons, etc
+   - refactor: refactoring code
+   - perf: performance improvements
+   - test: adding tests
+   - chore: maintenance tasks
+
+2. Scope is optional and should describe the section of code (e.g., feat(parser))
+3. Description must be concise and in imperative mood (e.g., 'change' not 'changed')
+4. Body should explain the motivation for the change and contrast with previous behavior
+5. Breaking changes MUST be indicated by BREAKING CHANGE: in footer
+6. A ! MAY be added before the : for breaking changes (e.g., feat!: breaking change)`
+
+// conventional implements Conventional Commits 1.0.0
+type conventional struct {
+	name   string
+	prompt string
+	types  []string
+	// bang allows "!" in the header to mark breaking changes
+	bang bool
+	// lowercase requires the description to start with a lowercase letter
+	lowercase bool
+}
+
+func init() {
+	register(conventional{
+		name:   "conventional",
+		prompt: conventionalPrompt,
+		types:  []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "chore", "build", "ci", "revert"},
+		bang:   true,
+	})
+}
+
+func (c conventional) Name() string {
+	return c.name
+}
+
+func (c conventional) Prompt() string {
+	return c.prompt
+}
+
+func (c conventional) Parse(message string) (Message, error) {
+	header, rest := splitMessage(message)
+
+	match := headerPattern.FindStringSubmatch(header)
+	if match == nil {
+		return Message{}, fmt.Errorf("not a %s commit: %q", c.name, header)
+	}
+
+	m := Message{
+		Type:        strings.ToLower(match[1]),
+		Scope:       strings.TrimSpace(match[2]),
+		Breaking:    match[3] == "!",
+		Description: strings.TrimSpace(match[4]),
+	}
+	splitBody(rest, &m)
+
+	return m, nil
+}
+
+func (c conventional) Validate(message string) []string {
+	problems := validateShape(message)
+
+	m, err := c.Parse(message)
+	if err != nil {
+		return append(problems, "header must look like <type>[optional scope]: <description>")
+	}
+
+	if !contains(c.types, m.Type) {
+		problems = append(problems, fmt.Sprintf("type %q is not one of: %s", m.Type, strings.Join(c.types, ", ")))
+	}
+	if strings.HasSuffix(m.Description, ".") {
+		problems = append(problems, "description must not end with a period")
+	}
+	if !c.bang && strings.Contains(strings.SplitN(message, ":", 2)[0], "!") {
+		problems = append(problems, "breaking changes must be marked with a BREAKING CHANGE: footer, not !")
+	}
+	if first, _ := utf8.DecodeRuneInString(m.Description); c.lowercase && unicode.IsUpper(first) {
+		problems = append(problems, "description must start with a lowercase letter")
+	}
+
+	return problems
+}
+
+func (c conventional) Format(m Message) string {
+	header := m.Type
+	if m.Scope != "" {
+		header += "(" + m.Scope + ")"
+	}
+	if m.Breaking && c.bang {
+		header += "!"
+	}
+	header += ": " + m.Description
+
+	if m.Breaking && !c.bang && m.BreakingNote == "" {
+		m.Footers = append(m.Footers, Footer{Token: "BREAKING CHANGE", Value: m.Description})
+	}
+
+	return joinMessage(header, m)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
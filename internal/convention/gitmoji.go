@@ -0,0 +1,141 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const gitmojiPrompt = `Generate a commit message following the gitmoji convention:
+
+<gitmoji> [(optional scope):] <description>
+
+[optional body]
+
+Rules:
+1. Start with the one gitmoji that best describes the change:
+   - ✨ new feature
+   - 🐛 bug fix
+   - 🚑️ critical hotfix
+   - 📝 documentation
+   - 🎨 structure or format of the code
+   - ♻️ refactoring
+   - ⚡️ performance
+   - ✅ tests
+   - 🔧 configuration
+   - ⬆️ dependency upgrade
+   - 🔥 removing code or files
+   - 🔒️ security fix
+   - 💥 breaking change
+
+2. Scope is optional and should describe the section of code (e.g., ✨ (parser): ...)
+3. Description must be concise, in imperative mood and start with a capital letter
+4. Body should explain the motivation for the change and contrast with previous behavior`
+
+// gitmojiTypes maps gitmoji, as emoji or :shortcode:, to Conventional Commit types
+var gitmojiTypes = map[string]string{
+	"✨": "feat", ":sparkles:": "feat",
+	"🐛": "fix", ":bug:": "fix",
+	"🚑️": "fix", "🚑": "fix", ":ambulance:": "fix",
+	"📝": "docs", ":memo:": "docs",
+	"🎨": "style", ":art:": "style",
+	"♻️": "refactor", "♻": "refactor", ":recycle:": "refactor",
+	"⚡️": "perf", "⚡": "perf", ":zap:": "perf",
+	"✅": "test", ":white_check_mark:": "test",
+	"🔧": "chore", ":wrench:": "chore",
+	"⬆️": "chore", "⬆": "chore", ":arrow_up:": "chore",
+	"🔥": "remove", ":fire:": "remove",
+	"🔒️": "security", "🔒": "security", ":lock:": "security",
+	"🗑️": "deprecate", "🗑": "deprecate", ":wastebasket:": "deprecate",
+	"⏪️": "revert", "⏪": "revert", ":rewind:": "revert",
+	"💥": "feat", ":boom:": "feat",
+}
+
+// gitmojiForType is the emoji Format writes for each type
+var gitmojiForType = map[string]string{
+	"feat":      "✨",
+	"fix":       "🐛",
+	"docs":      "📝",
+	"style":     "🎨",
+	"refactor":  "♻️",
+	"perf":      "⚡️",
+	"test":      "✅",
+	"chore":     "🔧",
+	"build":     "🔧",
+	"ci":        "🔧",
+	"remove":    "🔥",
+	"security":  "🔒️",
+	"deprecate": "🗑️",
+	"revert":    "⏪️",
+}
+
+var gitmojiPattern = regexp.MustCompile(`^(:[a-z0-9_+-]+:|[\x{1F300}-\x{1FAFF}\x{2600}-\x{27BF}\x{2B06}\x{23E9}-\x{23FA}][\x{FE0F}]?)\s*(?:\(([^()]*)\):?\s*)?(.+)$`)
+
+type gitmoji struct{}
+
+func init() {
+	register(gitmoji{})
+}
+
+func (gitmoji) Name() string {
+	return "gitmoji"
+}
+
+func (gitmoji) Prompt() string {
+	return gitmojiPrompt
+}
+
+func (gitmoji) Parse(message string) (Message, error) {
+	subject, rest := splitMessage(message)
+
+	match := gitmojiPattern.FindStringSubmatch(subject)
+	if match == nil {
+		return Message{}, fmt.Errorf("not a gitmoji commit: %q", subject)
+	}
+
+	m := Message{
+		Type:        gitmojiTypes[match[1]],
+		Scope:       strings.TrimSpace(match[2]),
+		Description: strings.TrimSpace(match[3]),
+		Breaking:    match[1] == "💥" || match[1] == ":boom:",
+	}
+	splitBody(rest, &m)
+
+	return m, nil
+}
+
+func (g gitmoji) Validate(message string) []string {
+	problems := validateShape(message)
+
+	subject, _ := splitMessage(message)
+	match := gitmojiPattern.FindStringSubmatch(subject)
+	if match == nil {
+		return append(problems, "subject must start with a gitmoji")
+	}
+	if _, ok := gitmojiTypes[match[1]]; !ok {
+		problems = append(problems, fmt.Sprintf("unknown gitmoji %s", match[1]))
+	}
+	if strings.HasSuffix(match[3], ".") {
+		problems = append(problems, "description must not end with a period")
+	}
+
+	return problems
+}
+
+func (gitmoji) Format(m Message) string {
+	emoji := gitmojiForType[m.Type]
+	if m.Breaking {
+		emoji = "💥"
+	}
+	if emoji == "" {
+		emoji = "🔧"
+	}
+
+	subject := emoji + " "
+	if m.Scope != "" {
+		subject += "(" + m.Scope + "): "
+	}
+	subject += m.Description
+
+	return joinMessage(subject, m)
+}
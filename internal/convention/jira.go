@@ -0,0 +1,75 @@
+package convention
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const jiraPrompt = `Generate a commit message prefixed with the issue key:
+
+<ISSUE-KEY>: <description>
+
+[optional body]
+
+Rules:
+1. The subject starts with the issue key in uppercase (e.g., PROJ-123: ), followed by the description
+2. Use the issue key given in the hint or context; if none is given, write only the description and never invent a key
+3. Description must be concise, in imperative mood and start with a capital letter (e.g., 'Add' not 'Added')
+4. Body should explain the motivation for the change and contrast with previous behavior`
+
+var jiraPattern = regexp.MustCompile(`^\[?([A-Z][A-Z0-9]+-\d+)\]?:?\s+(.+)$`)
+
+type jira struct{}
+
+func init() {
+	register(jira{})
+}
+
+func (jira) Name() string {
+	return "jira"
+}
+
+func (jira) Prompt() string {
+	return jiraPrompt
+}
+
+func (jira) Parse(message string) (Message, error) {
+	subject, rest := splitMessage(message)
+
+	match := jiraPattern.FindStringSubmatch(subject)
+	if match == nil {
+		return Message{}, fmt.Errorf("not a jira-prefixed commit: %q", subject)
+	}
+
+	m := Message{
+		Ticket:      match[1],
+		Description: strings.TrimSpace(match[2]),
+		Type:        inferType(match[2]),
+	}
+	splitBody(rest, &m)
+
+	return m, nil
+}
+
+func (j jira) Validate(message string) []string {
+	problems := validateShape(message)
+
+	m, err := j.Parse(message)
+	if err != nil {
+		return append(problems, "subject must start with an issue key, e.g. PROJ-123: ")
+	}
+	if strings.HasSuffix(m.Description, ".") {
+		problems = append(problems, "description must not end with a period")
+	}
+
+	return problems
+}
+
+func (jira) Format(m Message) string {
+	subject := m.Description
+	if m.Ticket != "" {
+		subject = m.Ticket + ": " + subject
+	}
+	return joinMessage(subject, m)
+}
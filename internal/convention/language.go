@@ -0,0 +1,53 @@
+package convention
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nonImperative lists common English verb forms that are not imperative
+var nonImperative = map[string]string{
+	"adds": "add", "added": "add", "adding": "add",
+	"fixes": "fix", "fixed": "fix", "fixing": "fix",
+	"updates": "update", "updated": "update", "updating": "update",
+	"removes": "remove", "removed": "remove", "removing": "remove",
+	"changes": "change", "changed": "change", "changing": "change",
+	"improves": "improve", "improved": "improve", "improving": "improve",
+	"implements": "implement", "implemented": "implement", "implementing": "implement",
+	"creates": "create", "created": "create", "creating": "create",
+	"refactors": "refactor", "refactored": "refactor", "refactoring": "refactor",
+	"moves": "move", "moved": "move", "moving": "move",
+	"renames": "rename", "renamed": "rename", "renaming": "rename",
+	"deletes": "delete", "deleted": "delete", "deleting": "delete",
+	"introduces": "introduce", "introduced": "introduce", "introducing": "introduce",
+	"supports": "support", "supported": "support", "supporting": "support",
+	"bumps": "bump", "bumped": "bump", "bumping": "bump",
+	"makes": "make", "made": "make", "making": "make",
+	"uses": "use", "used": "use", "using": "use",
+	"allows": "allow", "allowed": "allow", "allowing": "allow",
+	"prevents": "prevent", "prevented": "prevent", "preventing": "prevent",
+	"handles": "handle", "handled": "handle", "handling": "handle",
+}
+
+// ValidateIn validates a message with the convention and adds the checks that only
+// make sense in the message's language. The imperative-mood check only runs for
+// English; messages in other languages are judged on their format alone.
+func ValidateIn(c Convention, message, language string) []string {
+	problems := c.Validate(message)
+
+	if language != "" && !strings.EqualFold(language, "English") {
+		return problems
+	}
+
+	m, err := c.Parse(message)
+	if err != nil || m.Description == "" {
+		return problems
+	}
+
+	first := strings.ToLower(strings.Trim(strings.Fields(m.Description)[0], ".,:;"))
+	if base, ok := nonImperative[first]; ok {
+		problems = append(problems, fmt.Sprintf("description should use the imperative mood (%q, not %q)", base, first))
+	}
+
+	return problems
+}
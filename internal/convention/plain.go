@@ -0,0 +1,62 @@
+package convention
+
+import (
+	"fmt"
+	"strings"
+)
+
+const plainPrompt = `Generate a plain commit message:
+
+<subject>
+
+[optional body]
+
+Rules:
+1. The subject is a single descriptive line starting with a capitalized verb in imperative mood (e.g., 'Add' not 'Added')
+2. Do not use type prefixes, scopes, issue keys or emoji
+3. Keep the subject under 72 characters without a period at the end
+4. Body should explain the motivation for the change and contrast with previous behavior`
+
+type plain struct{}
+
+func init() {
+	register(plain{})
+}
+
+func (plain) Name() string {
+	return "plain"
+}
+
+func (plain) Prompt() string {
+	return plainPrompt
+}
+
+func (plain) Parse(message string) (Message, error) {
+	subject, rest := splitMessage(message)
+	if subject == "" {
+		return Message{}, fmt.Errorf("message is empty")
+	}
+
+	m := Message{
+		Description: subject,
+		Type:        inferType(subject),
+	}
+	splitBody(rest, &m)
+
+	return m, nil
+}
+
+func (plain) Validate(message string) []string {
+	problems := validateShape(message)
+
+	subject, _ := splitMessage(message)
+	if strings.HasSuffix(subject, ".") {
+		problems = append(problems, "subject must not end with a period")
+	}
+
+	return problems
+}
+
+func (plain) Format(m Message) string {
+	return joinMessage(m.Description, m)
+}
@@ -0,0 +1,46 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Edit opens content in the user's git editor and returns the saved text
+func Edit(content string) (string, error) {
+	file, err := os.CreateTemp("", "aigc-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", fmt.Errorf("error writing temporary file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	editor, err := exec.Command("git", "var", "GIT_EDITOR").Output()
+	if err != nil {
+		return "", fmt.Errorf("error resolving editor: %v", err)
+	}
+
+	// Run through the shell like git does, so editors configured with arguments work
+	cmd := exec.Command("sh", "-c", strings.TrimSpace(string(editor))+` "$@"`, "editor", file.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor exited with error: %v", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("error reading edited file: %v", err)
+	}
+
+	return string(data), nil
+}
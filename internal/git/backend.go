@@ -0,0 +1,22 @@
+package git
+
+import "fmt"
+
+// Backends selectable with the git.backend config key
+const (
+	// BackendExec runs the git binary, the default
+	BackendExec = "exec"
+	// BackendGo uses go-git and works without git installed
+	BackendGo = "go"
+)
+
+// OpenBackend opens the repository containing dir with the named backend
+func OpenBackend(backend, dir string) (Client, error) {
+	switch backend {
+	case "", BackendExec:
+		return Open(dir)
+	case BackendGo:
+		return OpenGoGit(dir)
+	}
+	return nil, fmt.Errorf("unknown git backend %q, must be %s or %s", backend, BackendExec, BackendGo)
+}
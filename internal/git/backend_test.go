@@ -0,0 +1,29 @@
+package git_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/git/gittest"
+)
+
+func TestExecBackendContract(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	if err := gittest.VerifyContract(t.TempDir(), func(dir string) (git.Client, error) {
+		return git.Open(dir)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGoGitBackendContract(t *testing.T) {
+	if err := gittest.VerifyContract(t.TempDir(), func(dir string) (git.Client, error) {
+		return git.OpenGoGit(dir)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,116 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultBase guesses the branch a feature branch will be merged into: the remote's
+// default branch when known, otherwise the first of main or master that exists
+func (r *Repository) DefaultBase() (string, error) {
+	if ref, err := r.run("rev-parse", "--abbrev-ref", "origin/HEAD"); err == nil && ref != "" && ref != "origin/HEAD" {
+		return ref, nil
+	}
+
+	for _, candidate := range []string{"origin/main", "origin/master", "main", "master"} {
+		if _, err := r.ResolveCommit(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not determine the base branch, please pass it explicitly")
+}
+
+// GetLog returns the full messages of the commits in a revision range, oldest first
+func (r *Repository) GetLog(revRange string) (string, error) {
+	output, err := r.run("log", "--reverse", "--no-merges", "--format=commit %h%n%B", revRange)
+	if err != nil {
+		return "", fmt.Errorf("error reading log of %s: %w", revRange, err)
+	}
+
+	if strings.TrimSpace(output) == "" {
+		return "", fmt.Errorf("no commits found in %s", revRange)
+	}
+
+	return output, nil
+}
+
+// GetBranchChanges returns the combined diff of HEAD since it diverged from base
+func (r *Repository) GetBranchChanges(base string) (string, error) {
+	changes, err := r.run("diff", "--stat", "--patch", base+"...HEAD")
+	if err != nil {
+		return "", fmt.Errorf("error reading changes since %s: %w", base, err)
+	}
+
+	if strings.TrimSpace(changes) == "" {
+		return "", fmt.Errorf("no changes found since %s", base)
+	}
+
+	return changes, nil
+}
+
+// GitPath resolves a path inside the .git directory, e.g. SQUASH_MSG
+func (r *Repository) GitPath(name string) (string, error) {
+	path, err := r.run("rev-parse", "--git-path", name)
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s: %w", name, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.root, path)
+	}
+	return path, nil
+}
+
+// LastTag returns the most recent tag reachable from HEAD, or "" when there is none
+func (r *Repository) LastTag() (string, error) {
+	if _, err := r.ResolveCommit("HEAD"); err != nil {
+		return "", err
+	}
+
+	tag, err := r.run("describe", "--tags", "--abbrev=0")
+	if err != nil {
+		return "", nil
+	}
+	return tag, nil
+}
+
+// RecentCommits returns up to limit non-merge commits reachable from HEAD, newest first
+func (r *Repository) RecentCommits(limit int) ([]Commit, error) {
+	output, err := r.run("log", "--no-merges", fmt.Sprintf("--max-count=%d", limit), "--format=%H%x00%s%x00%B%x1e", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("error reading recent commits: %w", err)
+	}
+	return parseCommits(output), nil
+}
+
+// CurrentBranch returns the checked out branch, or "" on a detached HEAD
+func (r *Repository) CurrentBranch() string {
+	branch, err := r.run("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// ChangedFiles returns the paths touched by a commit, or by the staged changes when rev is empty
+func (r *Repository) ChangedFiles(rev string) ([]string, error) {
+	// -z keeps paths with spaces or special characters intact and unquoted
+	args := []string{"diff", "--cached", "--name-only", "-z"}
+	if rev != "" {
+		args = []string{"show", "--format=", "--name-only", "-z", rev}
+	}
+
+	output, err := r.run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing changed files: %w", err)
+	}
+
+	var files []string
+	for _, path := range strings.Split(output, "\x00") {
+		if path = strings.TrimLeft(path, "\n"); path != "" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
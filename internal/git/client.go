@@ -0,0 +1,55 @@
+package git
+
+// Client is everything the commands need from a repository. *Repository implements it
+// by running git; command handlers depend on Client so they can be exercised against
+// a fake repository.
+type Client interface {
+	// Root returns the absolute path of the working tree root
+	Root() string
+	Config(key string) (string, error)
+	Status() ([]FileStatus, error)
+
+	// Changes
+	GetStagedChanges() (string, error)
+	GetStagedHunks() ([]Hunk, error)
+	GetCommitChanges(rev string) (string, error)
+	GetBranchChanges(base string) (string, error)
+	ChangedFiles(rev string) ([]string, error)
+
+	// History
+	ResolveCommit(rev string) (string, error)
+	GetLog(revRange string) (string, error)
+	ListCommits(revRange string) ([]Commit, error)
+	RecentCommits(limit int) ([]Commit, error)
+	IsPushed(rev string) (bool, error)
+
+	// Branches and tags
+	CurrentBranch() string
+	DefaultBase() (string, error)
+	BranchTickets(patterns []string) ([]string, error)
+	LastTag() (string, error)
+	ListTags() ([]string, error)
+	CreateTag(name, message string) error
+	GitPath(name string) (string, error)
+
+	// Committing
+	SetSigning(s Signing)
+	CheckCommitMessage(message string) error
+	Commit(message string) error
+	Amend(message string) error
+	Push(opts PushOptions) (PushResult, error)
+	VerifySigned(rev string) error
+	AddTrailers(message string, trailers []Trailer) (string, error)
+	SignOff() (Trailer, error)
+
+	// Rewriting history and the index
+	Reword(rev, message string) (string, error)
+	RewriteMessages(messages map[string]string) (string, error)
+	SaveIndex() (string, error)
+	RestoreIndex(tree string) error
+	ResetIndex() error
+	ApplyToIndex(patch string) error
+	ResetHead(rev string) error
+}
+
+var _ Client = (*Repository)(nil)
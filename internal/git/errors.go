@@ -0,0 +1,57 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+var (
+	// ErrNotRepository is returned when a directory is not inside a git working tree
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrNoChanges is returned when there are no staged changes to work with
+	ErrNoChanges = errors.New("no staged changes found")
+	// ErrUnknownRevision is returned when a revision does not resolve to a commit
+	ErrUnknownRevision = errors.New("unknown commit")
+	// ErrUnsupported is returned for operations the selected backend cannot perform
+	ErrUnsupported = errors.New("not supported")
+)
+
+// Error is returned when a git command exits with an error. Stdout and stderr are
+// captured separately, so Stderr holds git's own explanation of the failure.
+type Error struct {
+	// Args are the arguments git was run with, without the leading "git"
+	Args []string
+	// ExitCode is git's exit status, or -1 if git could not be started
+	ExitCode int
+	// Stderr is git's error output with surrounding whitespace removed
+	Stderr string
+	Err    error
+}
+
+func newError(args []string, stderr string, err error) *Error {
+	e := &Error{
+		Args:     args,
+		ExitCode: -1,
+		Stderr:   strings.TrimSpace(stderr),
+		Err:      err,
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		e.ExitCode = exitErr.ExitCode()
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Stderr != "" {
+		return fmt.Sprintf("%v: %s", e.Err, e.Stderr)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
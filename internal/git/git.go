@@ -1,62 +1,146 @@
 package git
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strings"
 )
 
-type Git struct {
-	shouldPush bool
+// Repository runs git commands against one working tree. Every command runs in the
+// repository root, whatever the process's working directory.
+type Repository struct {
+	root    string
+	signing Signing
 }
 
-func New(shouldPush bool) *Git {
-	return &Git{
-		shouldPush: shouldPush,
+// Open returns the repository containing dir, resolving its root with
+// `git rev-parse --show-toplevel`. It fails with ErrNotRepository outside a working tree.
+func Open(dir string) (*Repository, error) {
+	root, err := (&Repository{root: dir}).run("rev-parse", "--show-toplevel")
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, fmt.Errorf("git is not installed, install it or use the go backend: %w", err)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotRepository, dir)
+	}
+	return &Repository{root: root}, nil
 }
 
-func (g *Git) GetStagedChanges() (string, error) {
-	// Stage all changes
-	stageCmd := exec.Command("git", "add", ".")
-	if err := stageCmd.Run(); err != nil {
-		return "", fmt.Errorf("error staging changes: %v", err)
+// Root returns the absolute path of the repository's working tree root
+func (r *Repository) Root() string {
+	return r.root
+}
+
+// GetStagedChanges stages every change in the working tree and returns the staged
+// files with their status
+func (r *Repository) GetStagedChanges() (string, error) {
+	if _, err := r.run("add", "."); err != nil {
+		return "", fmt.Errorf("error staging changes: %w", err)
 	}
 
-	// Get staged changes
-	cmd := exec.Command("git", "diff", "--cached", "--name-status")
-	output, err := cmd.Output()
+	changes, err := r.run("diff", "--cached", "--name-status")
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("error reading staged changes: %w", err)
 	}
 
-	changes := string(output)
 	if changes == "" {
-		return "", fmt.Errorf("no staged changes found")
+		return "", ErrNoChanges
 	}
 
 	return changes, nil
 }
 
-func (g *Git) Commit(message string) error {
-	cmd := exec.Command("git", "commit", "-m", message)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error committing changes: %v", err)
+// GetCommitChanges returns the patch introduced by the given commit against its parent
+func (r *Repository) GetCommitChanges(rev string) (string, error) {
+	changes, err := r.run("show", "--format=", "--stat", "--patch", rev)
+	if err != nil {
+		return "", fmt.Errorf("error reading changes of %s: %w", rev, err)
 	}
 
-	if g.shouldPush {
-		if err := g.Push(); err != nil {
-			return err
-		}
+	if strings.TrimSpace(changes) == "" {
+		return "", fmt.Errorf("no changes found in commit %s", rev)
 	}
 
-	return nil
+	return changes, nil
 }
 
-func (g *Git) Push() error {
-	cmd := exec.Command("git", "push")
-	output, err := cmd.CombinedOutput()
+// ResolveCommit resolves a revision to its full commit hash
+func (r *Repository) ResolveCommit(rev string) (string, error) {
+	sha, err := r.run("rev-parse", "--verify", "--quiet", rev+"^{commit}")
 	if err != nil {
-		return fmt.Errorf("failed to push changes: %v\n%s", err, output)
+		return "", fmt.Errorf("%w: %s", ErrUnknownRevision, rev)
 	}
-	return nil
+	return sha, nil
+}
+
+// IsPushed reports whether the commit is reachable from any remote-tracking branch
+func (r *Repository) IsPushed(rev string) (bool, error) {
+	output, err := r.run("branch", "--remotes", "--contains", rev)
+	if err != nil {
+		return false, fmt.Errorf("error checking remote branches: %w", err)
+	}
+	return output != "", nil
+}
+
+// Commit creates a commit from the index. Git's stderr, such as a signing or hook
+// failure, is included in the returned error.
+func (r *Repository) Commit(message string) error {
+	args := append([]string{"commit"}, r.signArgs()...)
+	if _, err := r.runWithEnv(nil, "", append(args, "-m", message)...); err != nil {
+		return fmt.Errorf("error committing changes: %w", err)
+	}
+
+	return r.checkHeadSigned()
+}
+
+// Amend replaces the message of HEAD, leaving any staged changes out of the commit
+func (r *Repository) Amend(message string) error {
+	args := append([]string{"commit", "--amend", "--only"}, r.signArgs()...)
+	if _, err := r.runWithEnv(nil, "", append(args, "-m", message)...); err != nil {
+		return fmt.Errorf("error amending commit: %w", err)
+	}
+
+	return r.checkHeadSigned()
+}
+
+// run executes a git command and returns its output without the trailing newline
+func (r *Repository) run(args ...string) (string, error) {
+	return r.runWithEnv(nil, "", args...)
+}
+
+// runWithEnv executes a git command in the repository root with extra environment
+// variables and optional stdin. Stdout is returned without the trailing newline; on
+// failure the error is an *Error carrying the exit code and stderr.
+func (r *Repository) runWithEnv(env []string, stdin string, args ...string) (string, error) {
+	return r.runCommand(env, stdin, nil, args...)
+}
+
+// runCommand is runWithEnv with git's stderr also copied to progress as it is written,
+// when progress is not nil
+func (r *Repository) runCommand(env []string, stdin string, progress io.Writer, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.root
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if progress != nil {
+		cmd.Stderr = io.MultiWriter(&stderr, progress)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return "", newError(args, stderr.String(), err)
+	}
+
+	return strings.TrimRight(stdout.String(), "\n"), nil
 }
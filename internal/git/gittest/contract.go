@@ -0,0 +1,669 @@
+// Package gittest checks git.Client implementations against the behavior the
+// commands rely on, so every backend can be held to the same contract.
+package gittest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// Opener opens the repository at dir with the backend under test
+type Opener func(dir string) (git.Client, error)
+
+// VerifyContract creates a fresh repository in dir, which must be empty, and runs the
+// contract scenario against the client returned by open. It returns the first
+// behavior that does not match, or nil. The repository is initialized with go-git so
+// the check itself does not need a git binary. A backend may refuse an operation with
+// git.ErrUnsupported, but must not do anything else instead.
+func VerifyContract(dir string, open Opener) error {
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		return fmt.Errorf("init: %v", err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return fmt.Errorf("init: %v", err)
+	}
+	cfg.User.Name = "Contract"
+	cfg.User.Email = "contract@example.com"
+	cfg.Raw.Section("commit").SetOption("gpgsign", "false")
+	if err := repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("init: %v", err)
+	}
+
+	c := &contract{dir: dir, repo: repo}
+	if c.client, err = open(dir); err != nil {
+		return fmt.Errorf("open: %v", err)
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"root", c.root},
+		{"config", c.config},
+		{"first commit", c.firstCommit},
+		{"tags", c.tags},
+		{"staged changes", c.stagedChanges},
+		{"history", c.history},
+		{"amend", c.amend},
+		{"errors", c.errors},
+		{"index", c.index},
+		{"rewrite", c.rewrite},
+		{"push", c.push},
+		{"merge order", c.mergeOrder},
+		{"hooks", c.hooks},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			return fmt.Errorf("%s: %v", step.name, err)
+		}
+	}
+	return nil
+}
+
+type contract struct {
+	dir    string
+	repo   *gogit.Repository
+	client git.Client
+}
+
+func (c *contract) write(name, content string) error {
+	return os.WriteFile(filepath.Join(c.dir, name), []byte(content), 0644)
+}
+
+func (c *contract) root() error {
+	want, err := filepath.EvalSymlinks(c.dir)
+	if err != nil {
+		return err
+	}
+	got, err := filepath.EvalSymlinks(c.client.Root())
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("Root() = %q, want %q", got, want)
+	}
+
+	path, err := c.client.GitPath("SQUASH_MSG")
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(path) || filepath.Base(path) != "SQUASH_MSG" {
+		return fmt.Errorf("GitPath(SQUASH_MSG) = %q, want an absolute path", path)
+	}
+	return nil
+}
+
+func (c *contract) config() error {
+	if name, err := c.client.Config("user.name"); err != nil || name != "Contract" {
+		return fmt.Errorf("Config(user.name) = %q, %v", name, err)
+	}
+	if value, err := c.client.Config("aigc.unset"); err != nil || value != "" {
+		return fmt.Errorf("Config(aigc.unset) = %q, %v, want empty", value, err)
+	}
+
+	trailer, err := c.client.SignOff()
+	if err != nil {
+		return err
+	}
+	if trailer.String() != "Signed-off-by: Contract <contract@example.com>" {
+		return fmt.Errorf("SignOff() = %q", trailer)
+	}
+	return nil
+}
+
+func (c *contract) firstCommit() error {
+	if err := c.write("a.txt", "one\n"); err != nil {
+		return err
+	}
+
+	status, err := c.client.Status()
+	if err != nil {
+		return err
+	}
+	if len(status) != 1 || status[0].Path != "a.txt" || status[0].Unstaged != '?' {
+		return fmt.Errorf("Status() = %+v, want untracked a.txt", status)
+	}
+
+	changes, err := c.client.GetStagedChanges()
+	if err != nil {
+		return err
+	}
+	if changes != "A\ta.txt" {
+		return fmt.Errorf("GetStagedChanges() = %q", changes)
+	}
+
+	if err := c.client.Commit("feat: add a\n"); err != nil {
+		return err
+	}
+
+	if branch := c.client.CurrentBranch(); branch == "" {
+		return fmt.Errorf("CurrentBranch() is empty after the first commit")
+	}
+	head, err := c.client.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+	if len(head) != 40 {
+		return fmt.Errorf("ResolveCommit(HEAD) = %q, want a full hash", head)
+	}
+
+	recent, err := c.client.RecentCommits(10)
+	if err != nil {
+		return err
+	}
+	if len(recent) != 1 || recent[0].Subject != "feat: add a" || recent[0].Message != "feat: add a" || recent[0].Hash != head {
+		return fmt.Errorf("RecentCommits() = %+v", recent)
+	}
+
+	changes, err = c.client.GetCommitChanges("HEAD")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(changes, "a.txt") || !strings.Contains(changes, "+one") {
+		return fmt.Errorf("GetCommitChanges(HEAD) = %q", changes)
+	}
+	return nil
+}
+
+func (c *contract) tags() error {
+	if tag, err := c.client.LastTag(); err != nil || tag != "" {
+		return fmt.Errorf("LastTag() = %q, %v, want none", tag, err)
+	}
+	if err := c.client.CreateTag("v0.1.0", "Release 0.1.0"); err != nil {
+		return err
+	}
+	if tag, err := c.client.LastTag(); err != nil || tag != "v0.1.0" {
+		return fmt.Errorf("LastTag() = %q, %v", tag, err)
+	}
+	if tags, err := c.client.ListTags(); err != nil || !reflect.DeepEqual(tags, []string{"v0.1.0"}) {
+		return fmt.Errorf("ListTags() = %v, %v", tags, err)
+	}
+	return nil
+}
+
+func (c *contract) stagedChanges() error {
+	if err := c.write("a.txt", "one\ntwo\n"); err != nil {
+		return err
+	}
+	if err := c.write("b file.txt", "b\n"); err != nil {
+		return err
+	}
+
+	changes, err := c.client.GetStagedChanges()
+	if err != nil {
+		return err
+	}
+	if changes != "M\ta.txt\nA\tb file.txt" {
+		return fmt.Errorf("GetStagedChanges() = %q", changes)
+	}
+
+	files, err := c.client.ChangedFiles("")
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(files, []string{"a.txt", "b file.txt"}) {
+		return fmt.Errorf("ChangedFiles(\"\") = %v", files)
+	}
+
+	return c.client.Commit("fix: update a\n\nAlso add b.")
+}
+
+func (c *contract) history() error {
+	commits, err := c.client.ListCommits("v0.1.0..HEAD")
+	if err != nil {
+		return err
+	}
+	if len(commits) != 1 || commits[0].Subject != "fix: update a" || commits[0].Message != "fix: update a\n\nAlso add b." {
+		return fmt.Errorf("ListCommits(v0.1.0..HEAD) = %+v", commits)
+	}
+
+	all, err := c.client.ListCommits("HEAD")
+	if err != nil {
+		return err
+	}
+	if len(all) != 2 || all[0].Subject != "feat: add a" || all[1].Subject != "fix: update a" {
+		return fmt.Errorf("ListCommits(HEAD) = %+v, want oldest first", all)
+	}
+
+	log, err := c.client.GetLog("v0.1.0..HEAD")
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(log, "commit ") || !strings.Contains(log, "fix: update a\n\nAlso add b.") || strings.Contains(log, "feat: add a") {
+		return fmt.Errorf("GetLog(v0.1.0..HEAD) = %q", log)
+	}
+
+	files, err := c.client.ChangedFiles("HEAD")
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(files, []string{"a.txt", "b file.txt"}) {
+		return fmt.Errorf("ChangedFiles(HEAD) = %v", files)
+	}
+
+	changes, err := c.client.GetBranchChanges("v0.1.0")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(changes, "+two") || !strings.Contains(changes, "b file.txt") {
+		return fmt.Errorf("GetBranchChanges(v0.1.0) = %q", changes)
+	}
+
+	if pushed, err := c.client.IsPushed("HEAD"); err != nil || pushed {
+		return fmt.Errorf("IsPushed(HEAD) = %v, %v, want false without remotes", pushed, err)
+	}
+	if tag, err := c.client.LastTag(); err != nil || tag != "v0.1.0" {
+		return fmt.Errorf("LastTag() = %q, %v after a new commit", tag, err)
+	}
+	return nil
+}
+
+func (c *contract) amend() error {
+	before, err := c.client.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	// Staged changes must stay out of an amended commit
+	if err := c.write("c.txt", "c\n"); err != nil {
+		return err
+	}
+	if _, err := c.client.GetStagedChanges(); err != nil {
+		return err
+	}
+
+	if err := c.client.Amend("fix: update a and add b"); err != nil {
+		return err
+	}
+
+	after, err := c.client.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+	if after == before {
+		return fmt.Errorf("HEAD did not change")
+	}
+
+	recent, err := c.client.RecentCommits(1)
+	if err != nil {
+		return err
+	}
+	if len(recent) != 1 || recent[0].Message != "fix: update a and add b" {
+		return fmt.Errorf("RecentCommits(1) = %+v after amend", recent)
+	}
+
+	files, err := c.client.ChangedFiles("HEAD")
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(files, []string{"a.txt", "b file.txt"}) {
+		return fmt.Errorf("ChangedFiles(HEAD) = %v after amend, staged c.txt leaked in", files)
+	}
+
+	return c.client.Commit("chore: add c")
+}
+
+func (c *contract) errors() error {
+	if _, err := c.client.GetStagedChanges(); !errors.Is(err, git.ErrNoChanges) {
+		return fmt.Errorf("GetStagedChanges() on a clean tree = %v, want ErrNoChanges", err)
+	}
+	if _, err := c.client.ResolveCommit("no-such-rev"); !errors.Is(err, git.ErrUnknownRevision) {
+		return fmt.Errorf("ResolveCommit(no-such-rev) = %v, want ErrUnknownRevision", err)
+	}
+	return nil
+}
+
+func (c *contract) index() error {
+	if err := c.write("a.txt", "one\ntwo\nthree\n"); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(c.dir, "docs"), 0755); err != nil {
+		return err
+	}
+	// docs.txt sorts before docs/ in a tree, though "docs" is a prefix of "docs.txt"
+	if err := c.write("docs/notes.txt", "notes\n"); err != nil {
+		return err
+	}
+	if err := c.write("docs.txt", "docs\n"); err != nil {
+		return err
+	}
+	if _, err := c.client.GetStagedChanges(); err != nil {
+		return err
+	}
+
+	// Every backend can save the index: the response cache keys on the staged tree
+	tree, err := c.client.SaveIndex()
+	if err != nil {
+		return err
+	}
+
+	hunks, err := c.client.GetStagedHunks()
+	switch {
+	case errors.Is(err, git.ErrUnsupported):
+		for name, op := range map[string]func() error{
+			"RestoreIndex": func() error { return c.client.RestoreIndex(tree) },
+			"ResetIndex":   c.client.ResetIndex,
+			"ApplyToIndex": func() error { return c.client.ApplyToIndex("") },
+		} {
+			if err := op(); !errors.Is(err, git.ErrUnsupported) {
+				return fmt.Errorf("%s() = %v, want ErrUnsupported like GetStagedHunks", name, err)
+			}
+		}
+	case err != nil:
+		return err
+	default:
+		if err := c.editIndex(tree, hunks); err != nil {
+			return err
+		}
+	}
+
+	if err := c.client.Commit("docs: add three"); err != nil {
+		return err
+	}
+	head, err := c.repo.Head()
+	if err != nil {
+		return err
+	}
+	commit, err := c.repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+	if commit.TreeHash.String() != tree {
+		return fmt.Errorf("SaveIndex() = %s, want %s, the tree committed from the same index", tree, commit.TreeHash)
+	}
+	return nil
+}
+
+// editIndex checks the hunk-level index operations of backends that support them
+func (c *contract) editIndex(tree string, hunks []git.Hunk) error {
+	if len(hunks) != 3 || hunks[0].File != "a.txt" || !strings.Contains(hunks[0].Patch(), "+three") {
+		return fmt.Errorf("GetStagedHunks() = %+v", hunks)
+	}
+
+	staged := []string{"a.txt", "docs.txt", "docs/notes.txt"}
+	if err := c.client.ResetIndex(); err != nil {
+		return err
+	}
+	if files, err := c.client.ChangedFiles(""); err != nil || len(files) != 0 {
+		return fmt.Errorf("ChangedFiles(\"\") = %v, %v after ResetIndex, want none", files, err)
+	}
+	if err := c.client.ApplyToIndex(git.BuildPatch(hunks)); err != nil {
+		return err
+	}
+	if files, err := c.client.ChangedFiles(""); err != nil || !reflect.DeepEqual(files, staged) {
+		return fmt.Errorf("ChangedFiles(\"\") = %v, %v after ApplyToIndex", files, err)
+	}
+	if err := c.client.ResetIndex(); err != nil {
+		return err
+	}
+	if err := c.client.RestoreIndex(tree); err != nil {
+		return err
+	}
+	if files, err := c.client.ChangedFiles(""); err != nil || !reflect.DeepEqual(files, staged) {
+		return fmt.Errorf("ChangedFiles(\"\") = %v, %v after RestoreIndex", files, err)
+	}
+	return nil
+}
+
+func (c *contract) rewrite() error {
+	before, err := c.client.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+	commits, err := c.client.ListCommits("v0.1.0..HEAD")
+	if err != nil {
+		return err
+	}
+	if len(commits) != 3 {
+		return fmt.Errorf("ListCommits(v0.1.0..HEAD) = %+v, want 3 commits", commits)
+	}
+
+	backup, err := c.client.Reword(commits[0].Hash, "fix: update a\n\nAlso add b.")
+	if err != nil {
+		return err
+	}
+	if want := "refs/aigc/backup/" + c.client.CurrentBranch(); backup != want {
+		return fmt.Errorf("Reword() backup ref = %q, want %q", backup, want)
+	}
+	if saved, err := c.client.ResolveCommit(backup); err != nil || saved != before {
+		return fmt.Errorf("ResolveCommit(%s) = %q, %v, want the previous HEAD", backup, saved, err)
+	}
+
+	rewritten, err := c.client.ListCommits("v0.1.0..HEAD")
+	if err != nil {
+		return err
+	}
+	if len(rewritten) != 3 || rewritten[0].Message != "fix: update a\n\nAlso add b." || rewritten[1].Message != commits[1].Message || rewritten[2].Message != commits[2].Message {
+		return fmt.Errorf("ListCommits(v0.1.0..HEAD) = %+v after Reword", rewritten)
+	}
+	if rewritten[1].Hash == commits[1].Hash || rewritten[2].Hash == commits[2].Hash {
+		return fmt.Errorf("descendants of the reworded commit were not recreated")
+	}
+	if files, err := c.client.ChangedFiles("HEAD"); err != nil || !reflect.DeepEqual(files, []string{"a.txt", "docs.txt", "docs/notes.txt"}) {
+		return fmt.Errorf("ChangedFiles(HEAD) = %v, %v after Reword, want the tree kept", files, err)
+	}
+	if status, err := c.client.Status(); err != nil || len(status) != 0 {
+		return fmt.Errorf("Status() = %+v, %v after Reword, want a clean tree", status, err)
+	}
+
+	if _, err := c.client.RewriteMessages(map[string]string{
+		rewritten[1].Hash: "chore: add c.txt",
+		rewritten[2].Hash: "docs: add the third line",
+	}); err != nil {
+		return err
+	}
+	recent, err := c.client.RecentCommits(3)
+	if err != nil {
+		return err
+	}
+	if len(recent) != 3 || recent[0].Subject != "docs: add the third line" || recent[1].Subject != "chore: add c.txt" || recent[2].Subject != "fix: update a" {
+		return fmt.Errorf("RecentCommits(3) = %+v after RewriteMessages", recent)
+	}
+
+	if err := c.client.ResetHead(before); err != nil {
+		return err
+	}
+	if head, err := c.client.ResolveCommit("HEAD"); err != nil || head != before {
+		return fmt.Errorf("ResolveCommit(HEAD) = %q, %v after ResetHead, want %q", head, err, before)
+	}
+	return nil
+}
+
+func (c *contract) push() error {
+	remote, err := os.MkdirTemp("", "aigc-contract-remote-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(remote)
+	if _, err := gogit.PlainInit(remote, true); err != nil {
+		return fmt.Errorf("init remote: %v", err)
+	}
+	if _, err := c.repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{remote}}); err != nil {
+		return fmt.Errorf("add remote: %v", err)
+	}
+
+	result, err := c.client.Push(git.PushOptions{})
+	if errors.Is(err, git.ErrUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if result.Remote != "origin" || result.Branch != c.client.CurrentBranch() || !result.SetUpstream {
+		return fmt.Errorf("Push() = %+v, want the branch pushed to origin with an upstream", result)
+	}
+	if pushed, err := c.client.IsPushed("HEAD"); err != nil || !pushed {
+		return fmt.Errorf("IsPushed(HEAD) = %v, %v after Push, want true", pushed, err)
+	}
+	return nil
+}
+
+// mergeOrder adds a merged side branch whose commits are dated before the commit on
+// the main line, so committer-date order and topological order differ:
+//
+//	base - side one (+1h) - side two (+2h) --------- merge (+4h)
+//	     \                                         /
+//	      main (+3h) ------------------------------
+func (c *contract) mergeOrder() error {
+	ref, err := c.repo.Head()
+	if err != nil {
+		return err
+	}
+	base, err := c.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return err
+	}
+
+	commit := func(message string, hours int, parents ...plumbing.Hash) (plumbing.Hash, error) {
+		sig := object.Signature{Name: "Contract", Email: "contract@example.com", When: base.Committer.When.Add(time.Duration(hours) * time.Hour)}
+		obj := c.repo.Storer.NewEncodedObject()
+		err := (&object.Commit{Author: sig, Committer: sig, Message: message + "\n", TreeHash: base.TreeHash, ParentHashes: parents}).Encode(obj)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return c.repo.Storer.SetEncodedObject(obj)
+	}
+	sideOne, err := commit("feat: side one", 1, base.Hash)
+	if err != nil {
+		return err
+	}
+	sideTwo, err := commit("feat: side two", 2, sideOne)
+	if err != nil {
+		return err
+	}
+	main, err := commit("fix: main", 3, base.Hash)
+	if err != nil {
+		return err
+	}
+	merge, err := commit("Merge branch 'side'", 4, main, sideTwo)
+	if err != nil {
+		return err
+	}
+	if err := c.repo.Storer.SetReference(plumbing.NewHashReference(ref.Name(), merge)); err != nil {
+		return err
+	}
+
+	revRange := base.Hash.String() + "..HEAD"
+	commits, err := c.client.ListCommits(revRange)
+	if err != nil {
+		return err
+	}
+	if got, want := subjects(commits), []string{"fix: main", "feat: side one", "feat: side two"}; !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("ListCommits(%s) = %q, want %q in topological order", revRange, got, want)
+	}
+
+	recent, err := c.client.RecentCommits(3)
+	if err != nil {
+		return err
+	}
+	if got, want := subjects(recent), []string{"fix: main", "feat: side two", "feat: side one"}; !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("RecentCommits(3) = %q, want %q by committer date", got, want)
+	}
+
+	log, err := c.client.GetLog(revRange)
+	if err != nil {
+		return err
+	}
+	if one, two, main := strings.Index(log, "feat: side one"), strings.Index(log, "feat: side two"), strings.Index(log, "fix: main"); one < 0 || one > two || two > main {
+		return fmt.Errorf("GetLog(%s) = %q, want oldest first by committer date", revRange, log)
+	}
+
+	// Rewording below a merge recreates the merge with both parents
+	if _, err := c.client.Reword(sideOne.String(), "feat: first side change"); err != nil {
+		return err
+	}
+	head, err := c.repo.Head()
+	if err != nil {
+		return err
+	}
+	rewritten, err := c.repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+	if rewritten.NumParents() != 2 || rewritten.ParentHashes[0] != main {
+		return fmt.Errorf("HEAD after Reword has parents %v, want %s and the rewritten side branch", rewritten.ParentHashes, main)
+	}
+	commits, err = c.client.ListCommits(revRange)
+	if err != nil {
+		return err
+	}
+	if got, want := subjects(commits), []string{"fix: main", "feat: first side change", "feat: side two"}; !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("ListCommits(%s) = %q after Reword", revRange, got)
+	}
+	return nil
+}
+
+func subjects(commits []git.Commit) []string {
+	list := make([]string, len(commits))
+	for i, commit := range commits {
+		list[i] = commit.Subject
+	}
+	return list
+}
+
+func (c *contract) hooks() error {
+	if _, err := exec.LookPath("sh"); err != nil {
+		// Hooks are shell scripts; nothing to check on systems without sh
+		return nil
+	}
+	if err := c.client.CheckCommitMessage("fix: anything"); err != nil {
+		return fmt.Errorf("CheckCommitMessage() = %v without a hook, want nil", err)
+	}
+
+	hooks, err := c.client.GitPath("hooks")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(hooks, 0755); err != nil {
+		return err
+	}
+	hook := filepath.Join(hooks, "commit-msg")
+	script := "#!/bin/sh\ngrep -q '^feat' \"$1\" || { echo 'subject must start with feat'; exit 1; }\n"
+	if err := os.WriteFile(hook, []byte(script), 0755); err != nil {
+		return err
+	}
+	defer os.Remove(hook)
+
+	var hookErr *git.HookError
+	if err := c.client.CheckCommitMessage("fix: handle nil"); !errors.As(err, &hookErr) || hookErr.Output != "subject must start with feat" {
+		return fmt.Errorf("CheckCommitMessage() = %v, want a *HookError with the hook's output", err)
+	}
+	if err := c.client.CheckCommitMessage("feat: add flag"); err != nil {
+		return fmt.Errorf("CheckCommitMessage() = %v for an accepted message", err)
+	}
+
+	// core.hooksPath replaces the hooks directory, relative to the working tree
+	if err := os.MkdirAll(filepath.Join(c.dir, ".githooks"), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, ".githooks", "commit-msg"), []byte("#!/bin/sh\necho rejected\nexit 1\n"), 0755); err != nil {
+		return err
+	}
+	cfg, err := c.repo.Config()
+	if err != nil {
+		return err
+	}
+	cfg.Raw.Section("core").SetOption("hooksPath", ".githooks")
+	if err := c.repo.SetConfig(cfg); err != nil {
+		return err
+	}
+	if err := c.client.CheckCommitMessage("feat: add flag"); !errors.As(err, &hookErr) || hookErr.Output != "rejected" {
+		return fmt.Errorf("CheckCommitMessage() = %v, want the core.hooksPath hook to reject it", err)
+	}
+	return nil
+}
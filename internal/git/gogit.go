@@ -0,0 +1,955 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// GoGitRepository implements Client with go-git, so staged changes, history, branches,
+// tags, commits and message rewrites work without a git binary. Only the commit-msg
+// hook is run, by CheckCommitMessage, and commits can only be created unsigned. Operations go-git has no equivalent for
+// (hunk-level index edits and push) return ErrUnsupported; it never runs git.
+type GoGitRepository struct {
+	root    string
+	repo    *gogit.Repository
+	signing Signing
+}
+
+var _ Client = (*GoGitRepository)(nil)
+
+// OpenGoGit returns the repository containing dir, read with go-git
+func OpenGoGit(dir string) (*GoGitRepository, error) {
+	repo, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotRepository, dir)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotRepository, dir)
+	}
+
+	return &GoGitRepository{root: wt.Filesystem.Root(), repo: repo}, nil
+}
+
+// Root returns the absolute path of the working tree root
+func (r *GoGitRepository) Root() string {
+	return r.root
+}
+
+// SetSigning changes how Commit and Amend sign commits, which for the go backend only
+// decides whether they fail
+func (r *GoGitRepository) SetSigning(s Signing) {
+	r.signing = s
+}
+
+// Config returns the value of a git config key from the local, global or system
+// config, in that order of precedence, or "" when it is not set
+func (r *GoGitRepository) Config(key string) (string, error) {
+	section, subsection, name := splitConfigKey(key)
+	if name == "" {
+		return "", fmt.Errorf("invalid config key %q", key)
+	}
+
+	local, err := r.repo.Config()
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", key, err)
+	}
+	configs := []*gitconfig.Config{local}
+	for _, scope := range []gitconfig.Scope{gitconfig.GlobalScope, gitconfig.SystemScope} {
+		if cfg, err := gitconfig.LoadConfig(scope); err == nil {
+			configs = append(configs, cfg)
+		}
+	}
+
+	for _, cfg := range configs {
+		if cfg.Raw == nil || !cfg.Raw.HasSection(section) {
+			continue
+		}
+		s := cfg.Raw.Section(section)
+		if subsection != "" {
+			if s.HasSubsection(subsection) && s.Subsection(subsection).HasOption(name) {
+				return s.Subsection(subsection).Option(name), nil
+			}
+			continue
+		}
+		if s.HasOption(name) {
+			return s.Option(name), nil
+		}
+	}
+	return "", nil
+}
+
+// splitConfigKey splits "section.subsection.name" into its parts
+func splitConfigKey(key string) (string, string, string) {
+	first := strings.Index(key, ".")
+	last := strings.LastIndex(key, ".")
+	if first < 0 {
+		return "", "", ""
+	}
+	if first == last {
+		return key[:first], "", key[last+1:]
+	}
+	return key[:first], key[first+1 : last], key[last+1:]
+}
+
+// Status returns the changed and untracked files of the working tree
+func (r *GoGitRepository) Status() ([]FileStatus, error) {
+	status, err := r.status()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []FileStatus
+	for path, s := range status {
+		if s.Staging == gogit.Unmodified && s.Worktree == gogit.Unmodified {
+			continue
+		}
+		entries = append(entries, FileStatus{Path: path, Staged: byte(s.Staging), Unstaged: byte(s.Worktree)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+func (r *GoGitRepository) status() (gogit.Status, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("error reading status: %w", err)
+	}
+	return status, nil
+}
+
+// GetStagedChanges stages every change in the working tree and returns the staged
+// files with their status
+func (r *GoGitRepository) GetStagedChanges() (string, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	if err := wt.AddWithOptions(&gogit.AddOptions{All: true}); err != nil {
+		return "", fmt.Errorf("error staging changes: %w", err)
+	}
+
+	files, err := r.stagedFiles()
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", ErrNoChanges
+	}
+
+	lines := make([]string, len(files))
+	for i, f := range files {
+		lines[i] = fmt.Sprintf("%c\t%s", f.Staged, f.Path)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// stagedFiles returns the files whose index entry differs from HEAD
+func (r *GoGitRepository) stagedFiles() ([]FileStatus, error) {
+	entries, err := r.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var staged []FileStatus
+	for _, e := range entries {
+		if e.Staged != byte(gogit.Unmodified) && e.Staged != byte(gogit.Untracked) {
+			staged = append(staged, e)
+		}
+	}
+	return staged, nil
+}
+
+// GetCommitChanges returns the patch introduced by the given commit against its parent
+func (r *GoGitRepository) GetCommitChanges(rev string) (string, error) {
+	c, err := r.commit(rev)
+	if err != nil {
+		return "", err
+	}
+
+	var parent *object.Commit
+	if c.NumParents() > 0 {
+		if parent, err = c.Parent(0); err != nil {
+			return "", fmt.Errorf("error reading changes of %s: %w", rev, err)
+		}
+	}
+
+	changes, err := diffCommits(parent, c)
+	if err != nil {
+		return "", fmt.Errorf("error reading changes of %s: %w", rev, err)
+	}
+	if strings.TrimSpace(changes) == "" {
+		return "", fmt.Errorf("no changes found in commit %s", rev)
+	}
+	return changes, nil
+}
+
+// GetBranchChanges returns the combined diff of HEAD since it diverged from base
+func (r *GoGitRepository) GetBranchChanges(base string) (string, error) {
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return "", err
+	}
+	baseCommit, err := r.commit(base)
+	if err != nil {
+		return "", err
+	}
+
+	bases, err := baseCommit.MergeBase(head)
+	if err != nil || len(bases) == 0 {
+		return "", fmt.Errorf("error reading changes since %s: no common ancestor", base)
+	}
+
+	changes, err := diffCommits(bases[0], head)
+	if err != nil {
+		return "", fmt.Errorf("error reading changes since %s: %w", base, err)
+	}
+	if strings.TrimSpace(changes) == "" {
+		return "", fmt.Errorf("no changes found since %s", base)
+	}
+	return changes, nil
+}
+
+// diffCommits renders the diff between two commits like `git diff --stat --patch`.
+// A nil from compares against the empty tree.
+func diffCommits(from, to *object.Commit) (string, error) {
+	var fromTree *object.Tree
+	if from != nil {
+		tree, err := from.Tree()
+		if err != nil {
+			return "", err
+		}
+		fromTree = tree
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return "", err
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", err
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return "", err
+	}
+	if len(patch.FilePatches()) == 0 {
+		return "", nil
+	}
+	return patch.Stats().String() + "\n" + patch.String(), nil
+}
+
+// ChangedFiles returns the paths touched by a commit, or by the staged changes when rev is empty
+func (r *GoGitRepository) ChangedFiles(rev string) ([]string, error) {
+	var files []string
+	if rev == "" {
+		staged, err := r.stagedFiles()
+		if err != nil {
+			return nil, fmt.Errorf("error listing changed files: %w", err)
+		}
+		for _, f := range staged {
+			files = append(files, f.Path)
+		}
+		return files, nil
+	}
+
+	c, err := r.commit(rev)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := c.Stats()
+	if err != nil {
+		return nil, fmt.Errorf("error listing changed files: %w", err)
+	}
+	for _, s := range stats {
+		files = append(files, s.Name)
+	}
+	return files, nil
+}
+
+// ResolveCommit resolves a revision to its full commit hash
+func (r *GoGitRepository) ResolveCommit(rev string) (string, error) {
+	c, err := r.commit(rev)
+	if err != nil {
+		return "", err
+	}
+	return c.Hash.String(), nil
+}
+
+// commit resolves a revision to a commit object, peeling annotated tags
+func (r *GoGitRepository) commit(rev string) (*object.Commit, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(strings.TrimSuffix(rev, "^{commit}")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRevision, rev)
+	}
+
+	if tag, err := r.repo.TagObject(*hash); err == nil {
+		c, err := tag.Commit()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRevision, rev)
+		}
+		return c, nil
+	}
+
+	c, err := r.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRevision, rev)
+	}
+	return c, nil
+}
+
+// GetLog returns the full messages of the commits in a revision range, oldest first
+// by committer date like `git log --reverse`
+func (r *GoGitRepository) GetLog(revRange string) (string, error) {
+	commits, err := r.revList(revRange, 0, false)
+	if err != nil {
+		return "", fmt.Errorf("error reading log of %s: %w", revRange, err)
+	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits found in %s", revRange)
+	}
+
+	var b strings.Builder
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		fmt.Fprintf(&b, "commit %s\n%s\n", shortHash(c.Hash), c.Message)
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// ListCommits returns the non-merge commits of a revision range, oldest first in
+// topological order like the exec backend, so parents always come before children
+func (r *GoGitRepository) ListCommits(revRange string) ([]Commit, error) {
+	commits, err := r.revList(revRange, 0, true)
+	if err != nil {
+		return nil, fmt.Errorf("error listing commits in %s: %w", revRange, err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found in %s", revRange)
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// RecentCommits returns up to limit non-merge commits reachable from HEAD, newest first
+func (r *GoGitRepository) RecentCommits(limit int) ([]Commit, error) {
+	commits, err := r.revList("HEAD", limit, false)
+	if err != nil {
+		return nil, fmt.Errorf("error reading recent commits: %w", err)
+	}
+	return commits, nil
+}
+
+// revList returns the non-merge commits of "to", "from..to" or "from...to" (treated
+// like "..") newest first, stopping after limit commits when limit is positive. They
+// are ordered by committer date like `git log`, or like `git log --topo-order` when
+// topo is set.
+func (r *GoGitRepository) revList(revRange string, limit int, topo bool) ([]Commit, error) {
+	from, to := "", revRange
+	if i := strings.Index(revRange, ".."); i >= 0 {
+		from, to = revRange[:i], strings.TrimPrefix(revRange[i+2:], ".")
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	exclude := map[plumbing.Hash]bool{}
+	if from != "" {
+		base, err := r.commit(from)
+		if err != nil {
+			return nil, err
+		}
+		if exclude, err = r.ancestors(base.Hash); err != nil {
+			return nil, err
+		}
+	}
+
+	tip, err := r.commit(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if topo {
+		ordered, err := r.topoOrder(tip.Hash, exclude)
+		if err != nil {
+			return nil, err
+		}
+		var commits []Commit
+		for _, c := range ordered {
+			if c.NumParents() > 1 {
+				continue
+			}
+			commits = append(commits, toCommit(c))
+			if limit > 0 && len(commits) >= limit {
+				break
+			}
+		}
+		return commits, nil
+	}
+
+	iter, err := r.repo.Log(&gogit.LogOptions{From: tip.Hash, Order: gogit.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var commits []Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if exclude[c.Hash] {
+			return nil
+		}
+		if c.NumParents() > 1 {
+			return nil
+		}
+		commits = append(commits, toCommit(c))
+		if limit > 0 && len(commits) >= limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return commits, err
+}
+
+// ancestors returns the set of commits reachable from hash, including itself
+func (r *GoGitRepository) ancestors(hash plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	iter, err := r.repo.Log(&gogit.LogOptions{From: hash})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	seen := map[plumbing.Hash]bool{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	})
+	return seen, err
+}
+
+func toCommit(c *object.Commit) Commit {
+	message := strings.TrimRight(c.Message, "\n")
+	subject, _, _ := strings.Cut(message, "\n")
+	return Commit{Hash: c.Hash.String(), Subject: subject, Message: message}
+}
+
+// IsPushed reports whether the commit is reachable from any remote-tracking branch
+func (r *GoGitRepository) IsPushed(rev string) (bool, error) {
+	c, err := r.commit(rev)
+	if err != nil {
+		return false, err
+	}
+
+	refs, err := r.repo.References()
+	if err != nil {
+		return false, fmt.Errorf("error checking remote branches: %w", err)
+	}
+	defer refs.Close()
+
+	pushed := false
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if !ref.Name().IsRemote() || ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		tip, err := r.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil
+		}
+		if tip.Hash == c.Hash {
+			pushed = true
+		} else if ok, err := c.IsAncestor(tip); err == nil && ok {
+			pushed = true
+		}
+		if pushed {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("error checking remote branches: %w", err)
+	}
+	return pushed, nil
+}
+
+// CurrentBranch returns the checked out branch, or "" on a detached HEAD
+func (r *GoGitRepository) CurrentBranch() string {
+	head, err := r.repo.Reference(plumbing.HEAD, false)
+	if err != nil || head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return ""
+	}
+	return head.Target().Short()
+}
+
+// BranchTickets returns the ticket IDs found in the current branch name
+func (r *GoGitRepository) BranchTickets(patterns []string) ([]string, error) {
+	branch := r.CurrentBranch()
+	if branch == "" {
+		return nil, nil
+	}
+	return ExtractTickets(branch, patterns)
+}
+
+// DefaultBase guesses the branch a feature branch will be merged into: the remote's
+// default branch when known, otherwise the first of main or master that exists
+func (r *GoGitRepository) DefaultBase() (string, error) {
+	if ref, err := r.repo.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false); err == nil && ref.Type() == plumbing.SymbolicReference {
+		return ref.Target().Short(), nil
+	}
+
+	for _, candidate := range []string{"origin/main", "origin/master", "main", "master"} {
+		if _, err := r.ResolveCommit(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not determine the base branch, please pass it explicitly")
+}
+
+// tagsByCommit maps every tagged commit to its tag names, peeling annotated tags
+func (r *GoGitRepository) tagsByCommit() (map[plumbing.Hash][]string, error) {
+	refs, err := r.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	defer refs.Close()
+
+	tags := map[plumbing.Hash][]string{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := r.repo.TagObject(hash); err == nil {
+			c, err := tag.Commit()
+			if err != nil {
+				return nil
+			}
+			hash = c.Hash
+		}
+		tags[hash] = append(tags[hash], ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	return tags, nil
+}
+
+// LastTag returns the tag closest to HEAD in its history, or "" when there is none
+func (r *GoGitRepository) LastTag() (string, error) {
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return "", err
+	}
+	tags, err := r.tagsByCommit()
+	if err != nil {
+		return "", err
+	}
+
+	// Breadth-first from HEAD, so the first tagged commit found is the nearest one
+	queue := []*object.Commit{head}
+	seen := map[plumbing.Hash]bool{head.Hash: true}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		if names := tags[c.Hash]; len(names) > 0 {
+			sort.Strings(names)
+			return names[len(names)-1], nil
+		}
+
+		err := c.Parents().ForEach(func(p *object.Commit) error {
+			if !seen[p.Hash] {
+				seen[p.Hash] = true
+				queue = append(queue, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// ListTags returns the tags reachable from HEAD
+func (r *GoGitRepository) ListTags() ([]string, error) {
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	reachable, err := r.ancestors(head.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	tags, err := r.tagsByCommit()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for hash, tagNames := range tags {
+		if reachable[hash] {
+			names = append(names, tagNames...)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CreateTag creates an annotated tag on HEAD with the given message
+func (r *GoGitRepository) CreateTag(name, message string) error {
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return err
+	}
+	tagger, err := r.signature()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.repo.CreateTag(name, head.Hash, &gogit.CreateTagOptions{Tagger: tagger, Message: message + "\n"}); err != nil {
+		return fmt.Errorf("error creating tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// GitPath resolves a path inside the .git directory, e.g. SQUASH_MSG
+func (r *GoGitRepository) GitPath(name string) (string, error) {
+	dir := filepath.Join(r.root, ".git")
+	if storage, ok := r.repo.Storer.(*filesystem.Storage); ok {
+		dir = storage.Filesystem().Root()
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// signature returns the identity of user.name and user.email, timestamped now
+func (r *GoGitRepository) signature() (*object.Signature, error) {
+	name, err := r.Config("user.name")
+	if err != nil {
+		return nil, err
+	}
+	email, err := r.Config("user.email")
+	if err != nil {
+		return nil, err
+	}
+	if name == "" || email == "" {
+		return nil, fmt.Errorf("user.name and user.email must be configured to commit")
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}, nil
+}
+
+// checkUnsigned fails when a signed commit is expected, since go-git cannot use
+// git's GPG or SSH signing setup
+func (r *GoGitRepository) checkUnsigned() error {
+	sign := r.signing.Mode == SignAlways || r.signing.Require
+	if r.signing.Mode == SignDefault {
+		if value, _ := r.Config("commit.gpgsign"); strings.EqualFold(value, "true") {
+			sign = true
+		}
+	}
+	if sign {
+		return fmt.Errorf("%w: signing commits with the go backend, use the exec backend", ErrUnsupported)
+	}
+	return nil
+}
+
+// CheckCommitMessage runs the commit-msg hook, if the repository has one, against
+// message without committing, like the exec backend. The hook script is executed
+// directly, so it works without git as long as the hook itself does not need it.
+func (r *GoGitRepository) CheckCommitMessage(message string) error {
+	hook, err := r.hookPath("commit-msg")
+	if err != nil || hook == "" {
+		return err
+	}
+
+	file, err := r.GitPath("AIGC_EDITMSG")
+	if err != nil {
+		return err
+	}
+	return runCommitMsgHook(r.root, hook, file, message)
+}
+
+// hookPath returns the executable hook git would run, honoring core.hooksPath, or ""
+// when the hook is not installed
+func (r *GoGitRepository) hookPath(name string) (string, error) {
+	dir, err := r.Config("core.hooksPath")
+	if err != nil {
+		return "", fmt.Errorf("error resolving the %s hook: %w", name, err)
+	}
+
+	switch {
+	case dir == "":
+		if dir, err = r.GitPath("hooks"); err != nil {
+			return "", err
+		}
+	case strings.HasPrefix(dir, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("error resolving the %s hook: %w", name, err)
+		}
+		dir = filepath.Join(home, dir[2:])
+	case !filepath.IsAbs(dir):
+		dir = filepath.Join(r.root, dir)
+	}
+	return executableHook(name, filepath.Join(dir, name))
+}
+
+// Commit creates a commit from the index
+func (r *GoGitRepository) Commit(message string) error {
+	if err := r.checkUnsigned(); err != nil {
+		return err
+	}
+	sig, err := r.signature()
+	if err != nil {
+		return err
+	}
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	if _, err := wt.Commit(cleanupMessage(message), &gogit.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		if errors.Is(err, gogit.ErrEmptyCommit) {
+			return fmt.Errorf("error committing changes: %w", ErrNoChanges)
+		}
+		return fmt.Errorf("error committing changes: %w", err)
+	}
+	return nil
+}
+
+// Amend replaces the message of HEAD, leaving any staged changes out of the commit.
+// The tree, parents and author of HEAD are kept.
+func (r *GoGitRepository) Amend(message string) error {
+	if err := r.checkUnsigned(); err != nil {
+		return err
+	}
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return err
+	}
+	committer, err := r.signature()
+	if err != nil {
+		return err
+	}
+
+	hash, err := r.writeCommit(&object.Commit{
+		Author:       head.Author,
+		Committer:    *committer,
+		Message:      cleanupMessage(message),
+		TreeHash:     head.TreeHash,
+		ParentHashes: head.ParentHashes,
+	})
+	if err != nil {
+		return fmt.Errorf("error amending commit: %w", err)
+	}
+	if err := r.updateHead(hash, head.Hash); err != nil {
+		return fmt.Errorf("error amending commit: %w", err)
+	}
+	return nil
+}
+
+// Push is not supported by the go backend, which has no access to git's credential
+// helpers and SSH setup
+func (r *GoGitRepository) Push(opts PushOptions) (PushResult, error) {
+	return PushResult{}, fmt.Errorf("%w: pushing with the go backend, use the exec backend", ErrUnsupported)
+}
+
+// GetStagedHunks is not supported by the go backend, which cannot apply partial patches
+func (r *GoGitRepository) GetStagedHunks() ([]Hunk, error) {
+	return nil, errIndexEdits
+}
+
+// SaveIndex writes the current index to tree objects, like `git write-tree`, and
+// returns the hash of the root tree
+func (r *GoGitRepository) SaveIndex() (string, error) {
+	idx, err := r.repo.Storer.Index()
+	if err != nil {
+		return "", fmt.Errorf("error saving index: %w", err)
+	}
+
+	root := &indexTree{}
+	for _, e := range idx.Entries {
+		if e.Stage != 0 {
+			return "", fmt.Errorf("error saving index: %s has unresolved conflicts", e.Name)
+		}
+		if e.IntentToAdd {
+			continue
+		}
+		root.add(strings.Split(e.Name, "/"), object.TreeEntry{Mode: e.Mode, Hash: e.Hash})
+	}
+
+	hash, err := r.writeTree(root)
+	if err != nil {
+		return "", fmt.Errorf("error saving index: %w", err)
+	}
+	return hash.String(), nil
+}
+
+// indexTree is a directory of the index being written out as tree objects
+type indexTree struct {
+	files map[string]object.TreeEntry
+	dirs  map[string]*indexTree
+}
+
+func (t *indexTree) add(path []string, entry object.TreeEntry) {
+	if len(path) == 1 {
+		if t.files == nil {
+			t.files = map[string]object.TreeEntry{}
+		}
+		entry.Name = path[0]
+		t.files[path[0]] = entry
+		return
+	}
+
+	if t.dirs == nil {
+		t.dirs = map[string]*indexTree{}
+	}
+	dir, ok := t.dirs[path[0]]
+	if !ok {
+		dir = &indexTree{}
+		t.dirs[path[0]] = dir
+	}
+	dir.add(path[1:], entry)
+}
+
+// writeTree stores t and its subdirectories as tree objects and returns the root hash
+func (r *GoGitRepository) writeTree(t *indexTree) (plumbing.Hash, error) {
+	var entries []object.TreeEntry
+	for _, entry := range t.files {
+		entries = append(entries, entry)
+	}
+	for name, dir := range t.dirs {
+		hash, err := r.writeTree(dir)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: hash})
+	}
+
+	// git sorts directories as if their names ended with a slash
+	sortName := func(e object.TreeEntry) string {
+		if e.Mode == filemode.Dir {
+			return e.Name + "/"
+		}
+		return e.Name
+	}
+	sort.Slice(entries, func(i, j int) bool { return sortName(entries[i]) < sortName(entries[j]) })
+
+	obj := r.repo.Storer.NewEncodedObject()
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return r.repo.Storer.SetEncodedObject(obj)
+}
+
+// RestoreIndex is not supported by the go backend
+func (r *GoGitRepository) RestoreIndex(tree string) error {
+	return errIndexEdits
+}
+
+// ResetIndex is not supported by the go backend
+func (r *GoGitRepository) ResetIndex() error {
+	return errIndexEdits
+}
+
+// ApplyToIndex is not supported by the go backend
+func (r *GoGitRepository) ApplyToIndex(patch string) error {
+	return errIndexEdits
+}
+
+// errIndexEdits is returned by the hunk-level index operations that split relies on
+var errIndexEdits = fmt.Errorf("%w: editing the index hunk by hunk with the go backend, use the exec backend", ErrUnsupported)
+
+// cleanupMessage trims a message and ends it with a newline, as `git commit -m` does
+func cleanupMessage(message string) string {
+	return strings.TrimSpace(message) + "\n"
+}
+
+// VerifySigned checks that a commit carries a signature. go-git cannot verify GPG or
+// SSH signatures against git's keyring, so any signature counts.
+func (r *GoGitRepository) VerifySigned(rev string) error {
+	c, err := r.commit(rev)
+	if err != nil {
+		return err
+	}
+	if c.PGPSignature == "" {
+		return fmt.Errorf("commit %s is not signed, but signing is required", shortHash(c.Hash.String()))
+	}
+	return nil
+}
+
+// SignOff returns the Signed-off-by trailer for the configured user.name and user.email
+func (r *GoGitRepository) SignOff() (Trailer, error) {
+	sig, err := r.signature()
+	if err != nil {
+		return Trailer{}, err
+	}
+	return Trailer{Token: "Signed-off-by", Value: fmt.Sprintf("%s <%s>", sig.Name, sig.Email)}, nil
+}
+
+// AddTrailers appends trailers to a message, extending its trailer block when it ends
+// with one. Like `git interpret-trailers --if-exists addIfDifferent`, a trailer already
+// present with the same value is not added again.
+func (r *GoGitRepository) AddTrailers(message string, trailers []Trailer) (string, error) {
+	message = strings.TrimSpace(message)
+	if len(trailers) == 0 {
+		return message, nil
+	}
+
+	paragraphs := strings.Split(message, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+	hasBlock := len(paragraphs) > 1 && isTrailerBlock(last)
+
+	existing := map[string]bool{}
+	if hasBlock {
+		for _, line := range strings.Split(last, "\n") {
+			existing[strings.ToLower(line)] = true
+		}
+	}
+
+	var lines []string
+	for _, t := range trailers {
+		if key := strings.ToLower(t.String()); !existing[key] {
+			existing[key] = true
+			lines = append(lines, t.String())
+		}
+	}
+	if len(lines) == 0 {
+		return message, nil
+	}
+
+	if hasBlock {
+		return message + "\n" + strings.Join(lines, "\n"), nil
+	}
+	return message + "\n\n" + strings.Join(lines, "\n"), nil
+}
+
+// isTrailerBlock reports whether every line of a paragraph is a "Token: value" trailer
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		token, _, ok := strings.Cut(line, ": ")
+		if !ok || token == "" || strings.ContainsAny(token, " \t") && token != "BREAKING CHANGE" {
+			return false
+		}
+	}
+	return true
+}
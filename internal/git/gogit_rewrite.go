@@ -0,0 +1,236 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Reword replaces the message of a single commit reachable from HEAD and returns
+// the backup ref pointing at the previous HEAD
+func (r *GoGitRepository) Reword(rev, message string) (string, error) {
+	sha, err := r.ResolveCommit(rev)
+	if err != nil {
+		return "", err
+	}
+	return r.RewriteMessages(map[string]string{sha: message})
+}
+
+// RewriteMessages replaces the messages of the given commits (keyed by full hash)
+// and recreates every descendant up to HEAD on top of them, like the exec backend:
+// trees, authors and dates are kept, the index and working tree are left alone, and
+// the previous HEAD is saved under a backup ref whose name is returned. Commits that
+// would have to be signed fail with ErrUnsupported before HEAD moves.
+func (r *GoGitRepository) RewriteMessages(messages map[string]string) (string, error) {
+	if len(messages) == 0 {
+		return "", fmt.Errorf("no commits to rewrite")
+	}
+
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	// Like the exec backend, walk down to the common ancestor of all rewritten commits
+	var base *object.Commit
+	for sha := range messages {
+		c, err := r.repo.CommitObject(plumbing.NewHash(sha))
+		if err != nil {
+			return "", fmt.Errorf("commit %s is not an ancestor of HEAD", shortHash(sha))
+		}
+		if c.Hash != head.Hash {
+			if ok, err := c.IsAncestor(head); err != nil || !ok {
+				return "", fmt.Errorf("commit %s is not an ancestor of HEAD", shortHash(sha))
+			}
+		}
+
+		if base == nil {
+			base = c
+			continue
+		}
+		bases, err := base.MergeBase(c)
+		if err != nil || len(bases) == 0 {
+			return "", fmt.Errorf("error listing commits to rewrite: no common ancestor of %s and %s", shortHash(base.Hash.String()), shortHash(sha))
+		}
+		base = bases[0]
+	}
+
+	exclude := map[plumbing.Hash]bool{}
+	for _, parent := range base.ParentHashes {
+		ancestors, err := r.ancestors(parent)
+		if err != nil {
+			return "", fmt.Errorf("error listing commits to rewrite: %w", err)
+		}
+		for hash := range ancestors {
+			exclude[hash] = true
+		}
+	}
+
+	commits, err := r.topoOrder(head.Hash, exclude)
+	if err != nil {
+		return "", fmt.Errorf("error listing commits to rewrite: %w", err)
+	}
+	if err := r.checkUnsigned(); err != nil {
+		return "", err
+	}
+	committer, err := r.signature()
+	if err != nil {
+		return "", err
+	}
+
+	rewritten := map[plumbing.Hash]plumbing.Hash{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+
+		changed := false
+		message := c.Message
+		if m, ok := messages[c.Hash.String()]; ok {
+			message = cleanupMessage(m)
+			changed = true
+		}
+		parents := append([]plumbing.Hash(nil), c.ParentHashes...)
+		for j, parent := range parents {
+			if newParent, ok := rewritten[parent]; ok {
+				parents[j] = newParent
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		// The exec backend keeps signed commits signed, which go-git cannot do
+		if c.PGPSignature != "" && r.signing.Mode == SignDefault {
+			return "", fmt.Errorf("%w: re-signing commit %s with the go backend, use the exec backend or --no-sign; nothing was rewritten", ErrUnsupported, shortHash(c.Hash.String()))
+		}
+
+		newHash, err := r.writeCommit(&object.Commit{
+			Author:       c.Author,
+			Committer:    object.Signature{Name: committer.Name, Email: committer.Email, When: c.Committer.When},
+			Message:      message,
+			TreeHash:     c.TreeHash,
+			ParentHashes: parents,
+		})
+		if err != nil {
+			return "", fmt.Errorf("error rewriting commit %s: %w", shortHash(c.Hash.String()), err)
+		}
+		rewritten[c.Hash] = newHash
+	}
+
+	newHead, ok := rewritten[head.Hash]
+	if !ok {
+		return "", fmt.Errorf("nothing was rewritten")
+	}
+
+	backupRef := plumbing.ReferenceName("refs/aigc/backup/HEAD")
+	if branch := r.CurrentBranch(); branch != "" {
+		backupRef = plumbing.ReferenceName("refs/aigc/backup/" + branch)
+	}
+	if err := r.repo.Storer.SetReference(plumbing.NewHashReference(backupRef, head.Hash)); err != nil {
+		return "", fmt.Errorf("error writing backup ref %s: %w", backupRef, err)
+	}
+
+	if err := r.updateHead(newHead, head.Hash); err != nil {
+		return "", fmt.Errorf("error updating HEAD: %w", err)
+	}
+	return backupRef.String(), nil
+}
+
+// ResetHead moves the current branch back to rev without touching the index or
+// working tree. An empty rev deletes the branch, returning it to the unborn state.
+func (r *GoGitRepository) ResetHead(rev string) error {
+	name := r.headTarget()
+	if rev == "" {
+		if err := r.repo.Storer.RemoveReference(name); err != nil {
+			return fmt.Errorf("error resetting HEAD: %w", err)
+		}
+		return nil
+	}
+
+	c, err := r.commit(rev)
+	if err != nil {
+		return err
+	}
+	if err := r.repo.Storer.SetReference(plumbing.NewHashReference(name, c.Hash)); err != nil {
+		return fmt.Errorf("error resetting HEAD: %w", err)
+	}
+	return nil
+}
+
+// topoOrder returns the commits reachable from tip that are not in exclude, merges
+// included, newest first in the order of `git rev-list --topo-order`: no parent before
+// all of its children, and the commits of each merged line of history kept together
+func (r *GoGitRepository) topoOrder(tip plumbing.Hash, exclude map[plumbing.Hash]bool) ([]*object.Commit, error) {
+	commits := map[plumbing.Hash]*object.Commit{}
+	pending := []plumbing.Hash{tip}
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if exclude[hash] || commits[hash] != nil {
+			continue
+		}
+		c, err := r.repo.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		commits[hash] = c
+		pending = append(pending, c.ParentHashes...)
+	}
+	if commits[tip] == nil {
+		return nil, nil
+	}
+
+	children := map[plumbing.Hash]int{}
+	for _, c := range commits {
+		for _, parent := range c.ParentHashes {
+			if commits[parent] != nil {
+				children[parent]++
+			}
+		}
+	}
+
+	// Like git, a commit is ready once all its children are listed, and the most
+	// recently readied commit goes next, so a merged branch is listed in one run
+	var order []*object.Commit
+	ready := []*object.Commit{commits[tip]}
+	for len(ready) > 0 {
+		c := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		order = append(order, c)
+
+		for _, parent := range c.ParentHashes {
+			if commits[parent] == nil {
+				continue
+			}
+			if children[parent]--; children[parent] == 0 {
+				ready = append(ready, commits[parent])
+			}
+		}
+	}
+	return order, nil
+}
+
+// writeCommit stores a commit object and returns its hash
+func (r *GoGitRepository) writeCommit(c *object.Commit) (plumbing.Hash, error) {
+	obj := r.repo.Storer.NewEncodedObject()
+	if err := c.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return r.repo.Storer.SetEncodedObject(obj)
+}
+
+// headTarget returns the branch HEAD points to, or HEAD itself when it is detached
+func (r *GoGitRepository) headTarget() plumbing.ReferenceName {
+	if ref, err := r.repo.Reference(plumbing.HEAD, false); err == nil && ref.Type() == plumbing.SymbolicReference {
+		return ref.Target()
+	}
+	return plumbing.HEAD
+}
+
+// updateHead moves the current branch (or a detached HEAD) from oldHead to newHead,
+// failing if it no longer points at oldHead
+func (r *GoGitRepository) updateHead(newHead, oldHead plumbing.Hash) error {
+	name := r.headTarget()
+	return r.repo.Storer.CheckAndSetReference(plumbing.NewHashReference(name, newHead), plumbing.NewHashReference(name, oldHead))
+}
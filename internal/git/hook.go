@@ -0,0 +1,96 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// HookError is returned when a git hook rejects a commit. Output holds everything the
+// hook printed, such as commitlint's list of problems.
+type HookError struct {
+	Hook   string
+	Output string
+	Err    error
+}
+
+func (e *HookError) Error() string {
+	if e.Output == "" {
+		return fmt.Sprintf("%s hook failed: %v", e.Hook, e.Err)
+	}
+	return fmt.Sprintf("%s hook failed: %v\n%s", e.Hook, e.Err, e.Output)
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// CheckCommitMessage runs the commit-msg hook, if the repository has one, against
+// message without committing. A rejection is returned as a *HookError, which lets
+// callers tell it apart from other commit failures and fix the message before
+// `git commit` runs the hook again for real.
+func (r *Repository) CheckCommitMessage(message string) error {
+	hook, err := r.hookPath("commit-msg")
+	if err != nil || hook == "" {
+		return err
+	}
+
+	file, err := r.GitPath("AIGC_EDITMSG")
+	if err != nil {
+		return err
+	}
+	return runCommitMsgHook(r.root, hook, file, message)
+}
+
+// runCommitMsgHook writes message to file and runs the commit-msg hook on it from the
+// working tree root, as git does
+func runCommitMsgHook(root, hook, file, message string) error {
+	if err := os.WriteFile(file, []byte(strings.TrimSpace(message)+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing commit message for the commit-msg hook: %w", err)
+	}
+	defer os.Remove(file)
+
+	cmd := exec.Command(hook, file)
+	cmd.Dir = root
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return &HookError{Hook: "commit-msg", Output: strings.TrimSpace(output.String()), Err: err}
+	}
+	return nil
+}
+
+// hookPath returns the executable hook git would run, honoring core.hooksPath, or ""
+// when the hook is not installed
+func (r *Repository) hookPath(name string) (string, error) {
+	path, err := r.run("rev-parse", "--git-path", "hooks/"+name)
+	if err != nil {
+		return "", fmt.Errorf("error resolving the %s hook: %w", name, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.root, path)
+	}
+	return executableHook(name, path)
+}
+
+// executableHook returns path if it is a hook git would run, or "" when it is missing
+func executableHook(name, path string) (string, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading the %s hook: %w", name, err)
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		// git ignores hooks that are not executable
+		return "", nil
+	}
+	return path, nil
+}
@@ -0,0 +1,124 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hunk is an independently applicable piece of the staged diff. Text changes are
+// split per "@@" hunk, while new, deleted, binary and mode-changed files are kept
+// whole because git cannot apply them partially.
+type Hunk struct {
+	ID     int
+	File   string
+	header string
+	body   string
+}
+
+// Patch returns the hunk as a standalone patch
+func (h Hunk) Patch() string {
+	return h.header + h.body
+}
+
+// Summary returns the hunk body, or the file header for whole-file changes
+func (h Hunk) Summary() string {
+	if h.body == "" {
+		return h.header
+	}
+	return h.body
+}
+
+// GetStagedHunks returns the staged diff split into hunks, numbered from 1
+func (r *Repository) GetStagedHunks() ([]Hunk, error) {
+	diff, err := r.run("diff", "--cached", "--no-renames", "--no-color", "--no-ext-diff", "--binary")
+	if err != nil {
+		return nil, fmt.Errorf("error reading staged diff: %w", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		return nil, ErrNoChanges
+	}
+
+	return parseHunks(diff + "\n"), nil
+}
+
+// BuildPatch concatenates hunks into one patch, keeping a single header per file
+func BuildPatch(hunks []Hunk) string {
+	var b strings.Builder
+	lastHeader := ""
+	for _, h := range hunks {
+		if h.header != lastHeader {
+			b.WriteString(h.header)
+			lastHeader = h.header
+		}
+		b.WriteString(h.body)
+	}
+	return b.String()
+}
+
+func parseHunks(diff string) []Hunk {
+	var hunks []Hunk
+
+	for _, file := range splitFiles(diff) {
+		header, rest, found := strings.Cut(file, "\n@@")
+		if !found || isWholeFile(header) {
+			hunks = append(hunks, Hunk{File: filePath(file), header: file})
+			continue
+		}
+
+		header += "\n"
+		for _, body := range strings.Split("@@"+rest, "\n@@") {
+			if !strings.HasPrefix(body, "@@") {
+				body = "@@" + body
+			}
+			if !strings.HasSuffix(body, "\n") {
+				body += "\n"
+			}
+			hunks = append(hunks, Hunk{File: filePath(header), header: header, body: body})
+		}
+	}
+
+	for i := range hunks {
+		hunks[i].ID = i + 1
+	}
+	return hunks
+}
+
+// splitFiles splits a diff into per-file sections starting with "diff --git"
+func splitFiles(diff string) []string {
+	parts := strings.Split(diff, "\ndiff --git ")
+
+	var files []string
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		if !strings.HasPrefix(part, "diff --git ") {
+			part = "diff --git " + part
+		}
+		// Give back the newline consumed by the split; binary patches need their blank line
+		if i < len(parts)-1 {
+			part += "\n"
+		}
+		files = append(files, part)
+	}
+	return files
+}
+
+func isWholeFile(header string) bool {
+	for _, marker := range []string{"\nnew file mode", "\ndeleted file mode", "\nold mode", "\nGIT binary patch"} {
+		if strings.Contains(header, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// filePath extracts the b/ path from the "diff --git a/x b/x" line
+func filePath(section string) string {
+	line, _, _ := strings.Cut(section, "\n")
+	if i := strings.LastIndex(line, " b/"); i >= 0 {
+		return line[i+3:]
+	}
+	return strings.TrimPrefix(line, "diff --git ")
+}
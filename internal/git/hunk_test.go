@@ -0,0 +1,92 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+const twoHunkDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-var a = 1
++var a = 2
+ var b = 1
+@@ -20,3 +20,3 @@ func main() {
+ 	x()
+-	y()
++	z()
+ }
+`
+
+const newFileDiff = `diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1,2 @@
++one
++two
+`
+
+func TestParseHunks(t *testing.T) {
+	tests := []struct {
+		name  string
+		diff  string
+		files []string
+		whole []bool
+	}{
+		{"one file, two hunks", twoHunkDiff, []string{"main.go", "main.go"}, []bool{false, false}},
+		{"new file is kept whole", newFileDiff, []string{"new.txt"}, []bool{true}},
+		{"hunks of several files", twoHunkDiff + newFileDiff, []string{"main.go", "main.go", "new.txt"}, []bool{false, false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hunks := parseHunks(tt.diff)
+
+			var files []string
+			var whole []bool
+			for i, h := range hunks {
+				if h.ID != i+1 {
+					t.Errorf("hunk %d has ID %d", i, h.ID)
+				}
+				files = append(files, h.File)
+				whole = append(whole, h.body == "")
+			}
+			if !reflect.DeepEqual(files, tt.files) {
+				t.Errorf("files = %v, want %v", files, tt.files)
+			}
+			if !reflect.DeepEqual(whole, tt.whole) {
+				t.Errorf("whole-file hunks = %v, want %v", whole, tt.whole)
+			}
+
+			if got := BuildPatch(hunks); got != tt.diff {
+				t.Errorf("BuildPatch of all hunks does not reproduce the diff:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestParseHunksPatch(t *testing.T) {
+	hunks := parseHunks(twoHunkDiff)
+	if len(hunks) != 2 {
+		t.Fatalf("got %d hunks, want 2", len(hunks))
+	}
+
+	want := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -20,3 +20,3 @@ func main() {
+ 	x()
+-	y()
++	z()
+ }
+`
+	if got := hunks[1].Patch(); got != want {
+		t.Errorf("Patch() = %q, want %q", got, want)
+	}
+}
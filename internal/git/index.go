@@ -0,0 +1,55 @@
+package git
+
+import "fmt"
+
+// SaveIndex writes the current index to a tree object and returns its hash
+func (r *Repository) SaveIndex() (string, error) {
+	tree, err := r.run("write-tree")
+	if err != nil {
+		return "", fmt.Errorf("error saving index: %w", err)
+	}
+	return tree, nil
+}
+
+// RestoreIndex replaces the index with a tree saved by SaveIndex
+func (r *Repository) RestoreIndex(tree string) error {
+	if _, err := r.run("read-tree", tree); err != nil {
+		return fmt.Errorf("error restoring index: %w", err)
+	}
+	return nil
+}
+
+// ResetIndex makes the index match HEAD, or empties it before the first commit
+func (r *Repository) ResetIndex() error {
+	args := []string{"read-tree", "HEAD"}
+	if _, err := r.ResolveCommit("HEAD"); err != nil {
+		args = []string{"read-tree", "--empty"}
+	}
+
+	if _, err := r.run(args...); err != nil {
+		return fmt.Errorf("error resetting index: %w", err)
+	}
+	return nil
+}
+
+// ApplyToIndex applies a patch to the index without touching the working tree
+func (r *Repository) ApplyToIndex(patch string) error {
+	if _, err := r.runWithEnv(nil, patch, "apply", "--cached", "--whitespace=nowarn", "-"); err != nil {
+		return fmt.Errorf("error applying patch to index: %w", err)
+	}
+	return nil
+}
+
+// ResetHead moves the current branch back to rev without touching the index or
+// working tree. An empty rev deletes the branch, returning it to the unborn state.
+func (r *Repository) ResetHead(rev string) error {
+	args := []string{"update-ref", "-m", "aigc: rollback", "HEAD", rev}
+	if rev == "" {
+		args = []string{"update-ref", "-d", "HEAD"}
+	}
+
+	if _, err := r.run(args...); err != nil {
+		return fmt.Errorf("error resetting HEAD: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PushOptions controls Push
+type PushOptions struct {
+	// Remote to push to; empty means the branch's upstream remote, or the default remote
+	Remote string
+	// ForceWithLease overwrites the remote branch only if it still points where the
+	// remote-tracking branch says, as needed after rewording pushed commits
+	ForceWithLease bool
+	// Tags also pushes every tag
+	Tags bool
+	// Progress receives git's progress output while pushing, if set
+	Progress io.Writer
+}
+
+// PushResult describes what Push did
+type PushResult struct {
+	Remote string
+	Branch string
+	// SetUpstream is set when the branch had no upstream and now tracks Remote/Branch
+	SetUpstream bool
+}
+
+// Push pushes the current branch. A branch without an upstream, such as a new
+// feature branch, is pushed with -u so it tracks the remote branch afterwards.
+func (r *Repository) Push(opts PushOptions) (PushResult, error) {
+	branch := r.CurrentBranch()
+	if branch == "" {
+		return PushResult{}, fmt.Errorf("cannot push a detached HEAD, check out a branch first")
+	}
+
+	upstreamRemote, err := r.Config("branch." + branch + ".remote")
+	if err != nil {
+		return PushResult{}, err
+	}
+	upstreamMerge, err := r.Config("branch." + branch + ".merge")
+	if err != nil {
+		return PushResult{}, err
+	}
+
+	result := PushResult{Remote: opts.Remote, Branch: branch}
+	if result.Remote == "" {
+		result.Remote = upstreamRemote
+	}
+	if result.Remote == "" {
+		if result.Remote, err = r.defaultRemote(); err != nil {
+			return PushResult{}, err
+		}
+	}
+
+	// Push to the tracked branch, which may be named differently, or set the upstream
+	// when there is none. Pushing to another remote leaves the upstream alone.
+	refspec := branch
+	switch {
+	case upstreamRemote == "" || upstreamMerge == "":
+		result.SetUpstream = true
+	case result.Remote == upstreamRemote:
+		refspec = branch + ":" + upstreamMerge
+	}
+
+	args := []string{"push", "--progress"}
+	if result.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	if opts.ForceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+	if opts.Tags {
+		args = append(args, "--tags")
+	}
+	args = append(args, result.Remote, refspec)
+
+	if _, err := r.runCommand(nil, "", opts.Progress, args...); err != nil {
+		var gitErr *Error
+		if opts.Progress != nil && errors.As(err, &gitErr) {
+			// git's explanation was already shown along with the progress
+			err = gitErr.Err
+		}
+		return PushResult{}, fmt.Errorf("failed to push %s to %s: %w", branch, result.Remote, err)
+	}
+	return result, nil
+}
+
+// defaultRemote returns origin, or the only remote when there is exactly one
+func (r *Repository) defaultRemote() (string, error) {
+	output, err := r.run("remote")
+	if err != nil {
+		return "", fmt.Errorf("error listing remotes: %w", err)
+	}
+
+	remotes := strings.Fields(output)
+	for _, remote := range remotes {
+		if remote == "origin" {
+			return remote, nil
+		}
+	}
+	switch len(remotes) {
+	case 0:
+		return "", fmt.Errorf("no remote configured, add one with `git remote add`")
+	case 1:
+		return remotes[0], nil
+	}
+	return "", fmt.Errorf("several remotes and none is origin, choose one with --remote")
+}
@@ -0,0 +1,243 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// commitInfo holds the parts of a commit that are carried over when it is recreated
+type commitInfo struct {
+	tree        string
+	parents     []string
+	authorName  string
+	authorEmail string
+	authorDate  string
+	commitDate  string
+	message     string
+	signed      bool
+}
+
+// Commit describes a commit in a range
+type Commit struct {
+	Hash    string
+	Subject string
+	Message string
+}
+
+// ListCommits returns the non-merge commits of a revision range, oldest first
+func (r *Repository) ListCommits(revRange string) ([]Commit, error) {
+	output, err := r.run("log", "--reverse", "--topo-order", "--no-merges", "--format=%H%x00%s%x00%B%x1e", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("error listing commits in %s: %w", revRange, err)
+	}
+
+	commits := parseCommits(output)
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found in %s", revRange)
+	}
+
+	return commits, nil
+}
+
+// parseCommits parses log output written with the "%H%x00%s%x00%B%x1e" format
+func parseCommits(output string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Message: strings.TrimSpace(fields[2]),
+		})
+	}
+	return commits
+}
+
+// Reword replaces the message of a single commit reachable from HEAD and returns
+// the backup ref pointing at the previous HEAD
+func (r *Repository) Reword(rev, message string) (string, error) {
+	sha, err := r.ResolveCommit(rev)
+	if err != nil {
+		return "", err
+	}
+	return r.RewriteMessages(map[string]string{sha: message})
+}
+
+// RewriteMessages replaces the messages of the given commits (keyed by full hash)
+// and recreates every descendant up to HEAD on top of them. Trees are reused as-is,
+// so the rewrite can never conflict and leaves the index and working tree untouched.
+// Authors and dates are preserved, and the previous HEAD is saved under a backup ref
+// whose name is returned. Recreated commits are signed following the signing settings,
+// and HEAD is left alone if signing is required and one of them ends up unsigned.
+func (r *Repository) RewriteMessages(messages map[string]string) (string, error) {
+	if len(messages) == 0 {
+		return "", fmt.Errorf("no commits to rewrite")
+	}
+
+	head, err := r.ResolveCommit("HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	targets := []string{"merge-base", "--octopus"}
+	for sha := range messages {
+		if _, err := r.run("merge-base", "--is-ancestor", sha, head); err != nil {
+			return "", fmt.Errorf("commit %s is not an ancestor of HEAD", shortHash(sha))
+		}
+		targets = append(targets, sha)
+	}
+
+	// Walk down to the common ancestor of all rewritten commits, not just to the parents
+	// of each one: a parent may itself be a rewritten commit or one of its descendants
+	base, err := r.run(targets...)
+	if err != nil {
+		return "", fmt.Errorf("error listing commits to rewrite: %w", err)
+	}
+
+	output, err := r.run("rev-list", "--reverse", "--topo-order", "HEAD", "--not", base+"^@")
+	if err != nil {
+		return "", fmt.Errorf("error listing commits to rewrite: %w", err)
+	}
+
+	gpgSign, _ := r.run("config", "--bool", "commit.gpgsign")
+
+	rewritten := map[string]string{}
+	for _, sha := range strings.Fields(output) {
+		info, err := r.readCommit(sha)
+		if err != nil {
+			return "", err
+		}
+
+		changed := false
+		if message, ok := messages[sha]; ok {
+			info.message = message
+			changed = true
+		}
+		for i, parent := range info.parents {
+			if newParent, ok := rewritten[parent]; ok {
+				info.parents[i] = newParent
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		newSHA, err := r.writeCommit(info, r.rewriteSignArgs(gpgSign == "true", info.signed))
+		if err != nil {
+			return "", fmt.Errorf("error rewriting commit %s: %w", shortHash(sha), err)
+		}
+		if err := r.checkSigned(newSHA); err != nil {
+			return "", fmt.Errorf("error rewriting commit %s: %w; nothing was rewritten", shortHash(sha), err)
+		}
+		rewritten[sha] = newSHA
+	}
+
+	newHead, ok := rewritten[head]
+	if !ok {
+		return "", fmt.Errorf("nothing was rewritten")
+	}
+
+	backupRef, err := r.writeBackupRef(head)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.updateHead(newHead, head, "aigc: reword"); err != nil {
+		return "", err
+	}
+
+	return backupRef, nil
+}
+
+// readCommit loads the tree, parents, author, dates, message and signature status of a commit
+func (r *Repository) readCommit(sha string) (commitInfo, error) {
+	output, err := r.run("log", "-1", "--date=raw", "--format=%T%x00%P%x00%an%x00%ae%x00%ad%x00%cd", sha)
+	if err != nil {
+		return commitInfo{}, fmt.Errorf("error reading commit %s: %w", shortHash(sha), err)
+	}
+
+	fields := strings.Split(output, "\x00")
+	if len(fields) != 6 {
+		return commitInfo{}, fmt.Errorf("unexpected metadata for commit %s", shortHash(sha))
+	}
+
+	message, err := r.run("log", "-1", "--format=%B", sha)
+	if err != nil {
+		return commitInfo{}, fmt.Errorf("error reading message of %s: %w", shortHash(sha), err)
+	}
+
+	signed, err := r.isSigned(sha)
+	if err != nil {
+		return commitInfo{}, err
+	}
+
+	return commitInfo{
+		tree:        fields[0],
+		parents:     strings.Fields(fields[1]),
+		authorName:  fields[2],
+		authorEmail: fields[3],
+		authorDate:  fields[4],
+		commitDate:  fields[5],
+		message:     message,
+		signed:      signed,
+	}, nil
+}
+
+// writeCommit creates a new commit object from info with the given signing flags and
+// returns its hash
+func (r *Repository) writeCommit(info commitInfo, signArgs []string) (string, error) {
+	args := append([]string{"commit-tree", info.tree}, signArgs...)
+	for _, parent := range info.parents {
+		args = append(args, "-p", parent)
+	}
+	args = append(args, "-F", "-")
+
+	env := []string{
+		"GIT_AUTHOR_NAME=" + info.authorName,
+		"GIT_AUTHOR_EMAIL=" + info.authorEmail,
+		"GIT_AUTHOR_DATE=" + info.authorDate,
+		"GIT_COMMITTER_DATE=" + info.commitDate,
+	}
+
+	return r.runWithEnv(env, info.message+"\n", args...)
+}
+
+// writeBackupRef records head under refs/aigc/backup/<branch> so a rewrite can be undone
+func (r *Repository) writeBackupRef(head string) (string, error) {
+	name := "HEAD"
+	if branch, err := r.run("symbolic-ref", "--quiet", "--short", "HEAD"); err == nil && branch != "" {
+		name = branch
+	}
+
+	ref := "refs/aigc/backup/" + name
+	if _, err := r.run("update-ref", "-m", "aigc: backup before reword", ref, head); err != nil {
+		return "", fmt.Errorf("error writing backup ref %s: %w", ref, err)
+	}
+	return ref, nil
+}
+
+// updateHead moves the current branch (or a detached HEAD) from oldHead to newHead
+func (r *Repository) updateHead(newHead, oldHead, reason string) error {
+	if ref, err := r.run("symbolic-ref", "--quiet", "HEAD"); err == nil && ref != "" {
+		if _, err := r.run("update-ref", "-m", reason, ref, newHead, oldHead); err != nil {
+			return fmt.Errorf("error updating %s: %w", ref, err)
+		}
+		return nil
+	}
+
+	if _, err := r.run("update-ref", "--no-deref", "-m", reason, "HEAD", newHead, oldHead); err != nil {
+		return fmt.Errorf("error updating HEAD: %w", err)
+	}
+	return nil
+}
+
+func shortHash(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
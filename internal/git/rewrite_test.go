@@ -0,0 +1,55 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRewriteMessagesAdjacentCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "--quiet")
+	gitRun(t, dir, "config", "user.name", "Rewrite")
+	gitRun(t, dir, "config", "user.email", "rewrite@example.com")
+	gitRun(t, dir, "config", "commit.gpgsign", "false")
+	for _, subject := range []string{"one", "two", "three", "four"} {
+		gitRun(t, dir, "commit", "--quiet", "--allow-empty", "-m", subject)
+	}
+
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits, err := repo.ListCommits("HEAD~3..HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// two and three are adjacent: the parent of three is itself rewritten
+	if _, err := repo.RewriteMessages(map[string]string{
+		commits[0].Hash: "feat: two",
+		commits[1].Hash: "feat: three",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := gitRun(t, dir, "log", "--format=%s")
+	if want := "four\nfeat: three\nfeat: two\none"; got != want {
+		t.Errorf("log after RewriteMessages =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SignMode selects whether commits are signed
+type SignMode int
+
+const (
+	// SignDefault leaves signing to git's commit.gpgsign setting
+	SignDefault SignMode = iota
+	// SignAlways signs every commit, with the configured key if any
+	SignAlways
+	// SignNever disables signing, even when commit.gpgsign is set
+	SignNever
+)
+
+// Signing controls how commits created by aigc are signed. GPG, SSH and X.509 signing
+// are all configured through git (gpg.format, user.signingkey), aigc only passes -S.
+type Signing struct {
+	Mode SignMode
+	// Key is passed as -S<key>, overriding user.signingkey
+	Key string
+	// Require makes Commit and Amend fail when the new commit ends up unsigned
+	Require bool
+}
+
+// SetSigning changes how Commit and Amend sign commits
+func (r *Repository) SetSigning(s Signing) {
+	r.signing = s
+}
+
+// signArgs returns the git commit flags for the signing mode
+func (r *Repository) signArgs() []string {
+	switch r.signing.Mode {
+	case SignAlways:
+		return []string{"--gpg-sign" + keySuffix(r.signing.Key)}
+	case SignNever:
+		return []string{"--no-gpg-sign"}
+	}
+	return nil
+}
+
+func keySuffix(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "=" + key
+}
+
+// checkSigned fails if signing is required and the commit rev carries no valid signature
+func (r *Repository) checkSigned(rev string) error {
+	if !r.signing.Require {
+		return nil
+	}
+	return r.VerifySigned(rev)
+}
+
+// checkHeadSigned is checkSigned for the commit just created at HEAD. The commit is
+// kept so nothing is lost.
+func (r *Repository) checkHeadSigned() error {
+	if err := r.checkSigned("HEAD"); err != nil {
+		return fmt.Errorf("%w; the commit was created anyway, sign it with `git commit --amend --no-edit -S`", err)
+	}
+	return nil
+}
+
+// rewriteSignArgs returns the commit-tree flags for recreating a commit. commit-tree
+// ignores commit.gpgsign, so in the default mode a recreated commit is signed when
+// commit.gpgsign is set or when the original commit was signed.
+func (r *Repository) rewriteSignArgs(gpgSign, wasSigned bool) []string {
+	if r.signing.Mode == SignDefault && (gpgSign || wasSigned) {
+		return []string{"--gpg-sign" + keySuffix(r.signing.Key)}
+	}
+	return r.signArgs()
+}
+
+// isSigned reports whether a commit carries a signature, without verifying it
+func (r *Repository) isSigned(rev string) (bool, error) {
+	object, err := r.run("cat-file", "commit", rev)
+	if err != nil {
+		return false, fmt.Errorf("error reading commit %s: %w", rev, err)
+	}
+	header, _, _ := strings.Cut(object, "\n\n")
+	return strings.Contains(header, "\ngpgsig ") || strings.Contains(header, "\ngpgsig-sha256 "), nil
+}
+
+// VerifySigned checks that a commit carries a signature git accepts. Signatures git
+// cannot check, for example because the key is not in the keyring, still count as
+// signed; missing and bad signatures are errors.
+func (r *Repository) VerifySigned(rev string) error {
+	signed, err := r.isSigned(rev)
+	if err != nil {
+		return err
+	}
+	if !signed {
+		return fmt.Errorf("commit %s is not signed, but signing is required", r.shortName(rev))
+	}
+
+	status, err := r.run("log", "-1", "--format=%G?", rev)
+	if err != nil {
+		return fmt.Errorf("error checking the signature of %s: %w", rev, err)
+	}
+	if status == "B" {
+		return fmt.Errorf("commit %s has a bad signature", r.shortName(rev))
+	}
+	return nil
+}
+
+// shortName abbreviates the commit rev points to, falling back to rev itself
+func (r *Repository) shortName(rev string) string {
+	if sha, err := r.run("rev-parse", "--short", rev); err == nil {
+		return sha
+	}
+	return rev
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FileStatus is one entry of `git status`: a path with its staged (X) and
+// working tree (Y) state letters, e.g. "M", "A" or "?" for untracked files
+type FileStatus struct {
+	Path     string
+	Staged   byte
+	Unstaged byte
+}
+
+// Status returns the changed and untracked files of the working tree
+func (r *Repository) Status() ([]FileStatus, error) {
+	output, err := r.run("status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, fmt.Errorf("error reading status: %w", err)
+	}
+
+	var entries []FileStatus
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) < 4 {
+			continue
+		}
+		entries = append(entries, FileStatus{Path: entry[3:], Staged: entry[0], Unstaged: entry[1]})
+		if entry[0] == 'R' || entry[0] == 'C' {
+			// Renames and copies are followed by their source path
+			i++
+		}
+	}
+	return entries, nil
+}
+
+// Config returns the value of a git config key, or "" when it is not set
+func (r *Repository) Config(key string) (string, error) {
+	value, err := r.run("config", "--get", key)
+	if err != nil {
+		var gitErr *Error
+		if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("error reading %s: %w", key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListTags returns the tags reachable from HEAD
+func (r *Repository) ListTags() ([]string, error) {
+	output, err := r.run("tag", "--merged", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	return strings.Fields(output), nil
+}
+
+// CreateTag creates an annotated tag on HEAD with the given message
+func (r *Repository) CreateTag(name, message string) error {
+	if _, err := r.runWithEnv(nil, message+"\n", "tag", "--annotate", "--file=-", name, "HEAD"); err != nil {
+		return fmt.Errorf("error creating tag %s: %w", name, err)
+	}
+	return nil
+}
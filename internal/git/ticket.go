@@ -0,0 +1,49 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultTicketPattern matches Jira-style issue keys such as PROJ-1234
+const DefaultTicketPattern = `[A-Z][A-Z0-9]+-\d+`
+
+// BranchTickets returns the ticket IDs found in the current branch name, or nil on a
+// detached HEAD. See ExtractTickets for how patterns are applied.
+func (r *Repository) BranchTickets(patterns []string) ([]string, error) {
+	branch := r.CurrentBranch()
+	if branch == "" {
+		return nil, nil
+	}
+	return ExtractTickets(branch, patterns)
+}
+
+// ExtractTickets matches every pattern against a branch name and returns the unique
+// ticket IDs in order of appearance. A pattern's first capture group is used as the ID
+// when it has one, otherwise the whole match. No patterns means DefaultTicketPattern.
+func ExtractTickets(branch string, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{DefaultTicketPattern}
+	}
+
+	var tickets []string
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket pattern %q: %w", pattern, err)
+		}
+
+		for _, match := range re.FindAllStringSubmatch(branch, -1) {
+			id := match[0]
+			if len(match) > 1 && match[1] != "" {
+				id = match[1]
+			}
+			if !seen[id] {
+				seen[id] = true
+				tickets = append(tickets, id)
+			}
+		}
+	}
+	return tickets, nil
+}
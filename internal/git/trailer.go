@@ -0,0 +1,53 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Trailer is a "Token: value" line at the end of a commit message, such as Co-authored-by
+type Trailer struct {
+	Token string
+	Value string
+}
+
+func (t Trailer) String() string {
+	return t.Token + ": " + t.Value
+}
+
+// AddTrailers appends trailers to a message with `git interpret-trailers`, so they are
+// merged into an existing trailer block and placed the way git itself would. A trailer
+// already present with the same value is not added again.
+func (r *Repository) AddTrailers(message string, trailers []Trailer) (string, error) {
+	if len(trailers) == 0 {
+		return message, nil
+	}
+
+	args := []string{"interpret-trailers", "--if-exists", "addIfDifferent"}
+	for _, t := range trailers {
+		args = append(args, "--trailer", t.String())
+	}
+
+	output, err := r.runWithEnv(nil, strings.TrimSpace(message)+"\n", args...)
+	if err != nil {
+		return "", fmt.Errorf("error adding trailers: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// SignOff returns the Signed-off-by trailer for the configured user.name and user.email
+func (r *Repository) SignOff() (Trailer, error) {
+	name, err := r.Config("user.name")
+	if err != nil {
+		return Trailer{}, err
+	}
+	email, err := r.Config("user.email")
+	if err != nil {
+		return Trailer{}, err
+	}
+	if name == "" || email == "" {
+		return Trailer{}, fmt.Errorf("user.name and user.email must be configured to sign off commits")
+	}
+
+	return Trailer{Token: "Signed-off-by", Value: fmt.Sprintf("%s <%s>", name, email)}, nil
+}
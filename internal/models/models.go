@@ -0,0 +1,116 @@
+// Package models lists the models of a provider, caching the list, and suggests
+// close matches for misspelled model IDs.
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dacsang97/aigc/internal/cache"
+	"github.com/dacsang97/aigc/internal/provider"
+)
+
+// TTL is how long a model list is reused before the provider is asked again
+const TTL = 24 * time.Hour
+
+// List returns the models of p, sorted by ID. The list is served from c when it was
+// fetched within the TTL, unless refresh is set; key identifies the provider and
+// endpoint.
+func List(p provider.Provider, c *cache.Cache, key string, refresh bool) ([]provider.Model, error) {
+	lister, ok := p.(provider.ModelLister)
+	if !ok {
+		return nil, fmt.Errorf("this provider cannot list its models")
+	}
+
+	if !refresh {
+		if entry, ok := c.Get(key); ok {
+			var models []provider.Model
+			if err := json.Unmarshal([]byte(entry.Response), &models); err == nil {
+				return models, nil
+			}
+		}
+	}
+
+	models, err := lister.ListModels()
+	if err != nil {
+		return nil, fmt.Errorf("error listing models: %w", err)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+
+	// The list is still useful when it cannot be cached
+	if data, err := json.Marshal(models); err == nil {
+		c.Put(key, cache.Entry{Response: string(data)})
+	}
+	return models, nil
+}
+
+// Find returns the model with the given ID
+func Find(models []provider.Model, id string) (provider.Model, bool) {
+	for _, m := range models {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return provider.Model{}, false
+}
+
+// Suggest returns up to n model IDs close to id, closest first. IDs containing id,
+// or contained in it, count as close, as do IDs within a few edits.
+func Suggest(models []provider.Model, id string, n int) []string {
+	type candidate struct {
+		id       string
+		distance int
+	}
+
+	query := strings.ToLower(id)
+	limit := len(query)/3 + 1
+	var candidates []candidate
+	for _, m := range models {
+		other := strings.ToLower(m.ID)
+		d := distance(query, other)
+		if strings.Contains(other, query) || strings.Contains(query, other) {
+			d = min(d, limit)
+		}
+		if d <= limit {
+			candidates = append(candidates, candidate{m.ID, d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].id < candidates[j].id
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < n; i++ {
+		suggestions = append(suggestions, candidates[i].id)
+	}
+	return suggestions
+}
+
+// distance is the Levenshtein distance between a and b
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,45 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const defaultPolishSystemTemplate = `You turn terse commit subjects into user-facing release notes.
+
+For each entry, write one short sentence that tells users what changed for them:
+- Start with a verb in past tense or describe the new behavior (e.g. "Added ...", "Login no longer ...")
+- Expand jargon and abbreviations, drop implementation details users do not care about
+- Do not add facts that are not in the entry, and do not mention commit types or hashes
+- Write the notes in %s
+
+Respond with JSON only, without backticks or explanation, in this exact shape:
+{"entries": ["<note for entry 1>", "<note for entry 2>", ...]}
+
+Return exactly one note per entry, in the same order.`
+
+const defaultPolishTemplate = `Rewrite these changelog entries (type, scope and subject):
+%s`
+
+// PolishEntry is a changelog entry sent to the model for rewriting
+type PolishEntry struct {
+	Type    string `json:"type"`
+	Scope   string `json:"scope,omitempty"`
+	Subject string `json:"subject"`
+}
+
+// BuildPolishMessages builds the message list for rewriting changelog entries into release notes
+func (g *Generator) BuildPolishMessages(entries []PolishEntry, rules []string) []Message {
+	data, _ := json.MarshalIndent(entries, "", "  ")
+
+	return []Message{
+		{
+			Role:    "system",
+			Content: withRules(fmt.Sprintf(defaultPolishSystemTemplate, g.language)+"\n", rules),
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf(defaultPolishTemplate, data),
+		},
+	}
+}
@@ -1,50 +1,57 @@
 package prompt
 
-const defaultSystemTemplate = `Generate a commit message following the Conventional Commits standard:
-
-<type>[optional scope]: <description>
-
-[optional body]
-
-[optional footer]
-
-Rules:
-1. Type MUST be one of:
-   - feat: new feature (correlates with MINOR version)
-   - fix: bug fix (correlates with PATCH version)
-   - docs: documentation changes
-   - style: formatting, missing semi colons, etc
-   - refactor: refactoring code
-   - perf: performance improvements
-   - test: adding tests
-   - chore: maintenance tasks
-
-2. Scope is optional and should describe the section of code (e.g., feat(parser))
-3. Description must be concise and in imperative mood (e.g., 'change' not 'changed')
-4. Body should explain the motivation for the change and contrast with previous behavior
-5. Breaking changes MUST be indicated by BREAKING CHANGE: in footer
-6. A ! MAY be added before the : for breaking changes (e.g., feat!: breaking change)
-
-IMPORTANT: Always generate the commit message in English, regardless of the input language.
-Do not include any explanation in your response, only return the commit message content.`
+// defaultSystemTemplate wraps the format description of the active convention
+const defaultSystemTemplate = `{{.Format}}
+
+IMPORTANT: Always generate the commit message in {{.Language}}, regardless of the input language.
+{{- if ne .Language "English"}}
+Keep the commit type keywords (such as feat or fix), the scope syntax and footer tokens (such as BREAKING CHANGE) in English;
+write only the description and body in {{.Language}}.
+{{- end}}
+Do not include any explanation in your response, only return the commit message content.
+{{- if .Examples}}
+
+Examples of good commit messages from this repository. Match their format, tone and level of detail,
+but describe the actual changes instead of copying them:
+{{- range .Examples}}
+<example>
+{{.}}
+</example>
+{{- end}}
+{{- end}}
+{{- if .Rules}}
+
+Project-specific rules:
+{{- range .Rules}}
+- {{.}}
+{{- end}}
+{{- end}}
+`
 
 const defaultUserTemplate = `User provided this commit message hint (which may be in any language):
-%s
+{{.Hint}}
 
 Please consider this message when generating the commit message. 
-Understand the meaning and translate the intent to English if needed, 
-but ensure the output follows the Conventional Commits standard and is in English.`
+Understand the meaning and translate the intent to {{.Language}} if needed, 
+but ensure the output follows the required commit message format and is in {{.Language}}.`
 
 const defaultChangeMessageTemplate = `Analyze these file changes and generate a commit message:
 """
-%s
+{{.Changes}}
 """
+{{- if .Branch}}
+
+Current branch: {{.Branch}}
+{{- end}}
+{{- if .Tickets}}
+Related tickets: {{range $i, $t := .Tickets}}{{if $i}}, {{end}}{{$t}}{{end}} (references are added automatically; do not repeat them)
+{{- end}}
 
 Guidelines:
-1. Use appropriate type based on the changes (feat for new features, fix for bugs, etc.)
-2. Add relevant scope if the changes are focused on a specific component
+1. Follow the commit message format described above, choosing the type or prefix that fits the changes
+2. Add relevant scope if the format supports it and the changes are focused on a specific component
 3. Description must be under 100 characters
-4. Include breaking changes in footer with BREAKING CHANGE: prefix if any
+4. Mark breaking changes the way the format requires
 5. Add detailed body explaining motivation and changes if significant
 6. Use issue/PR references in footer if relevant
 
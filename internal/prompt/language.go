@@ -0,0 +1,47 @@
+package prompt
+
+import "strings"
+
+// DefaultLanguage is the language commit messages are written in unless configured otherwise
+const DefaultLanguage = "English"
+
+// languageNames maps common language codes to the names used in prompts
+var languageNames = map[string]string{
+	"en": "English",
+	"vi": "Vietnamese",
+	"ja": "Japanese",
+	"zh": "Chinese",
+	"ko": "Korean",
+	"fr": "French",
+	"de": "German",
+	"es": "Spanish",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"it": "Italian",
+	"id": "Indonesian",
+	"th": "Thai",
+}
+
+// LanguageName normalizes a language setting such as "vi" or "japanese" to the name
+// used in prompts. Unknown values are passed through so any language can be requested.
+func LanguageName(language string) string {
+	language = strings.TrimSpace(language)
+	if language == "" {
+		return DefaultLanguage
+	}
+
+	if name, ok := languageNames[strings.ToLower(language)]; ok {
+		return name
+	}
+	for _, name := range languageNames {
+		if strings.EqualFold(name, language) {
+			return name
+		}
+	}
+	return language
+}
+
+// SetLanguage changes the language generated text is written in
+func (g *Generator) SetLanguage(language string) {
+	g.language = LanguageName(language)
+}
@@ -0,0 +1,78 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultPRSystemTemplate = `You write pull request titles and descriptions from a branch's commits and diff.
+
+The response format is:
+- First line: the pull request title, under 72 characters, in imperative mood, without a trailing period
+- Then an empty line
+- Then the pull request description in GitHub-flavored Markdown
+
+Write in %s, for reviewers who have not seen the code yet. Be specific and concise;
+do not invent changes, tests or issue numbers that the commits and diff do not support.
+Do not wrap the response in backticks and do not add any explanation.`
+
+const defaultPRBodyTemplate = `Use these sections for the description:
+
+## Summary
+One or two sentences on what the pull request does and why.
+
+## Changes
+A bullet list of the notable changes.
+
+## Testing
+How the change was or should be verified. Say so if the branch adds no tests.
+
+## Breaking Changes
+Any breaking change and the migration it requires, or "None".`
+
+const defaultPRRepoTemplate = `Fill in the repository's pull request template for the description.
+Keep its headings, checklists and order; replace placeholder text and comments with real content,
+and leave checklist items unchecked unless the commits show they are done:
+"""
+%s
+"""`
+
+const defaultPRChangeTemplate = `Write a pull request for this branch.
+
+These are the branch's commits, oldest first:
+"""
+%s
+"""
+
+This is the combined diff of the branch:
+"""
+%s
+"""`
+
+// BuildPRMessages builds the message list for a pull request title and description.
+// When repoTemplate is set, the model fills it in instead of the default sections.
+func (g *Generator) BuildPRMessages(log, changes, repoTemplate, userMessage string, rules []string) []Message {
+	system := fmt.Sprintf(defaultPRSystemTemplate, g.language) + "\n\n" + defaultPRBodyTemplate
+	if strings.TrimSpace(repoTemplate) != "" {
+		system = fmt.Sprintf(defaultPRSystemTemplate, g.language) + "\n\n" + fmt.Sprintf(defaultPRRepoTemplate, strings.TrimSpace(repoTemplate))
+	}
+
+	messages := []Message{
+		{
+			Role:    "system",
+			Content: withRules(system+"\n", rules),
+		},
+	}
+
+	if userMessage != "" {
+		messages = append(messages, Message{
+			Role:    "user",
+			Content: fmt.Sprintf("The author describes the pull request as (possibly in another language):\n%s", userMessage),
+		})
+	}
+
+	return append(messages, Message{
+		Role:    "user",
+		Content: fmt.Sprintf(defaultPRChangeTemplate, log, changes),
+	})
+}
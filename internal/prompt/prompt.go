@@ -1,48 +1,120 @@
 package prompt
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/dacsang97/aigc/internal/convention"
+)
 
 // Generator handles the generation of prompts for AI models
 type Generator struct {
-	systemTemplate string
-	userTemplate   string
+	templates  *Templates
+	convention convention.Convention
+	examples   []string
+	context    Context
+	language   string
+}
+
+// Context is repository information made available to templates
+type Context struct {
+	Files         []string
+	Branch        string
+	RecentCommits []string
+	Tickets       []string
 }
 
 // New creates a new prompt generator with default templates
 func New() *Generator {
 	return &Generator{
-		systemTemplate: defaultSystemTemplate,
-		userTemplate:   defaultUserTemplate,
+		templates:  DefaultTemplates(),
+		convention: convention.Default(),
+		language:   DefaultLanguage,
 	}
 }
 
+// SetConvention changes the commit message format described to the model
+func (g *Generator) SetConvention(c convention.Convention) {
+	g.convention = c
+}
+
+// SetExamples adds messages from the repository's history as few-shot examples
+func (g *Generator) SetExamples(examples []string) {
+	g.examples = examples
+}
+
+// SetTemplates replaces the templates used to build commit message prompts
+func (g *Generator) SetTemplates(t *Templates) {
+	g.templates = t
+}
+
+// SetContext sets the repository information available to templates
+func (g *Generator) SetContext(ctx Context) {
+	g.context = ctx
+}
+
 // BuildMessages builds the complete message list for the AI model
-func (g *Generator) BuildMessages(changes, userMessage string, rules []string) []Message {
+func (g *Generator) BuildMessages(changes, userMessage string, rules []string) ([]Message, error) {
+	data := g.data(rules)
+	data.Changes = changes
+	data.Hint = userMessage
+
+	systemContent, err := g.templates.execute(SystemTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+
 	messages := []Message{
 		{
 			Role:    "system",
-			Content: g.buildSystemMessage(rules),
+			Content: systemContent,
 		},
 	}
 
 	if userMessage != "" {
+		hintContent, err := g.templates.execute(HintTemplate, data)
+		if err != nil {
+			return nil, err
+		}
 		messages = append(messages, Message{
 			Role:    "user",
-			Content: g.buildUserHintMessage(userMessage),
+			Content: hintContent,
 		})
 	}
 
+	changeContent, err := g.templates.execute(ChangesTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+
 	messages = append(messages, Message{
 		Role:    "user",
-		Content: g.buildChangeMessage(changes),
+		Content: changeContent,
 	})
 
-	return messages
+	return messages, nil
 }
 
-func (g *Generator) buildSystemMessage(rules []string) string {
-	systemContent := g.systemTemplate
+// data fills the template data shared by every template
+func (g *Generator) data(rules []string) TemplateData {
+	return TemplateData{
+		Files:         g.context.Files,
+		Branch:        g.context.Branch,
+		Rules:         rules,
+		RecentCommits: g.context.RecentCommits,
+		Tickets:       g.context.Tickets,
+		Language:      g.language,
+		Convention:    g.convention.Name(),
+		Format:        g.convention.Prompt(),
+		Examples:      g.examples,
+	}
+}
+
+func (g *Generator) buildSystemMessage(rules []string) (string, error) {
+	return g.templates.execute(SystemTemplate, g.data(rules))
+}
 
+// withRules appends the project-specific rules to a system prompt
+func withRules(systemContent string, rules []string) string {
 	if len(rules) > 0 {
 		systemContent += "\nProject-specific rules:\n"
 		for _, rule := range rules {
@@ -54,10 +126,8 @@ func (g *Generator) buildSystemMessage(rules []string) string {
 	return systemContent
 }
 
-func (g *Generator) buildUserHintMessage(hint string) string {
-	return fmt.Sprintf(g.userTemplate, hint)
-}
-
-func (g *Generator) buildChangeMessage(changes string) string {
-	return fmt.Sprintf(defaultChangeMessageTemplate, changes)
+func (g *Generator) buildUserHintMessage(hint string) (string, error) {
+	data := g.data(nil)
+	data.Hint = hint
+	return g.templates.execute(HintTemplate, data)
 }
@@ -0,0 +1,36 @@
+package prompt
+
+import "fmt"
+
+const defaultReleaseSystemTemplate = `You write the annotation of a release tag from its changelog.
+
+The response format is:
+- First line: "Release <version>" followed by a short theme of the release, under 72 characters
+- Then an empty line
+- Then two or three sentences summarizing what the release brings to users
+- Then the most important changes as a plain-text bullet list using "- "
+- If there are breaking changes, end with a "Breaking changes:" list describing the migration
+
+Write in %s, in plain text without Markdown headings, and do not invent changes
+that the changelog does not contain. Do not add any explanation.`
+
+const defaultReleaseTemplate = `Write the tag annotation for version %s.
+
+Changelog since %s:
+"""
+%s
+"""`
+
+// BuildReleaseMessages builds the message list for an AI-written release tag summary
+func (g *Generator) BuildReleaseMessages(version, previous, changelog string, rules []string) []Message {
+	return []Message{
+		{
+			Role:    "system",
+			Content: withRules(fmt.Sprintf(defaultReleaseSystemTemplate, g.language)+"\n", rules),
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf(defaultReleaseTemplate, version, previous, changelog),
+		},
+	}
+}
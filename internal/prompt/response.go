@@ -0,0 +1,13 @@
+package prompt
+
+import "strings"
+
+// ExtractJSON trims code fences or chatter around the first JSON object in a model reply
+func ExtractJSON(reply string) string {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return reply
+	}
+	return reply[start : end+1]
+}
@@ -0,0 +1,31 @@
+package prompt
+
+import "fmt"
+
+const defaultRejectionTemplate = `The commit message you generated was rejected by the repository's commit-msg hook:
+"""
+%s
+"""
+
+Hook output:
+"""
+%s
+"""
+
+Fix the commit message so it passes the hook, keeping it accurate for the changes.
+Return only the corrected commit message without any extra content or backticks.`
+
+// AppendRejection continues a conversation with the message the model generated and
+// the reason it was rejected, so the next completion corrects it
+func (g *Generator) AppendRejection(messages []Message, rejected, reason string) []Message {
+	return append(messages,
+		Message{
+			Role:    "assistant",
+			Content: rejected,
+		},
+		Message{
+			Role:    "user",
+			Content: fmt.Sprintf(defaultRejectionTemplate, rejected, reason),
+		},
+	)
+}
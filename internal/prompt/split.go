@@ -0,0 +1,38 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultSplitSystemTemplate = `You split a large staged change into a sequence of small, coherent commits.
+Each commit must group hunks that belong to the same logical concern, and the
+commits must be ordered so that each one makes sense on top of the previous ones.
+
+Every commit message must follow this format, in %s:
+
+%s
+
+Respond with JSON only, without backticks or explanation, in this exact shape:
+{"commits": [{"message": "<commit message>", "hunks": [<hunk ids>]}]}
+
+Every hunk id must appear in exactly one commit.`
+
+const defaultSplitChangeTemplate = `Group these staged hunks into commits:
+"""
+%s
+"""`
+
+// BuildSplitMessages builds the message list asking the model to group hunks into commits
+func (g *Generator) BuildSplitMessages(hunks string, rules []string) []Message {
+	return []Message{
+		{
+			Role:    "system",
+			Content: withRules(fmt.Sprintf(defaultSplitSystemTemplate, g.language, g.convention.Prompt())+"\n", rules),
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf(defaultSplitChangeTemplate, strings.TrimSpace(hunks)),
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package prompt
+
+import "fmt"
+
+const defaultSquashTemplate = `Write a single squash-merge commit message that summarizes this whole branch.
+
+These are the branch's commits, oldest first:
+"""
+%s
+"""
+
+This is the combined diff of the branch:
+"""
+%s
+"""
+
+Guidelines:
+1. Describe the overall change, not the individual commits or how the work progressed
+2. Pick the type that best describes the branch as a whole
+3. Description must be under 100 characters
+4. Summarize the notable changes as a short bullet list in the body
+5. Keep BREAKING CHANGE: and issue references from the commits in the footer
+
+Return only the commit message without any extra content or backticks.
+`
+
+// BuildSquashMessages builds the message list for summarizing a branch into one squash commit
+func (g *Generator) BuildSquashMessages(log, changes, userMessage string, rules []string) ([]Message, error) {
+	systemContent, err := g.buildSystemMessage(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []Message{
+		{
+			Role:    "system",
+			Content: systemContent,
+		},
+	}
+
+	if userMessage != "" {
+		hintContent, err := g.buildUserHintMessage(userMessage)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, Message{
+			Role:    "user",
+			Content: hintContent,
+		})
+	}
+
+	return append(messages, Message{
+		Role:    "user",
+		Content: fmt.Sprintf(defaultSquashTemplate, log, changes),
+	}), nil
+}
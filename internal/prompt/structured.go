@@ -0,0 +1,20 @@
+package prompt
+
+const defaultStructuredTemplate = `Return the commit message as a JSON object with these fields instead of plain text:
+- type: the kind of change as a Conventional Commits type (feat, fix, docs, style, refactor, perf, test, chore, build, ci or revert), even if the format above has no type
+- scope: the affected area in one or two words, or an empty string
+- subject: the description only, without type, scope, issue key or emoji
+- body: the body paragraphs, or an empty string
+- breaking: true if the change breaks backward compatibility
+- footers: a list of {"token": ..., "value": ...} footers, such as a "BREAKING CHANGE" note, or an empty list
+
+Return only the JSON object.`
+
+// AppendStructured asks for the commit message as a JSON object, so it can be
+// formatted according to the convention instead of parsed from free text
+func (g *Generator) AppendStructured(messages []Message) []Message {
+	return append(messages, Message{
+		Role:    "user",
+		Content: defaultStructuredTemplate,
+	})
+}
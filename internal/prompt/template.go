@@ -0,0 +1,155 @@
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Template names users can override with a <name>.tmpl file
+const (
+	SystemTemplate  = "system"
+	HintTemplate    = "hint"
+	ChangesTemplate = "changes"
+)
+
+// TemplateNames lists the overridable templates in the order they are sent
+var TemplateNames = []string{SystemTemplate, HintTemplate, ChangesTemplate}
+
+var defaultTemplates = map[string]string{
+	SystemTemplate:  defaultSystemTemplate,
+	HintTemplate:    defaultUserTemplate,
+	ChangesTemplate: defaultChangeMessageTemplate,
+}
+
+// TemplateData is the data model available to prompt templates
+type TemplateData struct {
+	// Changes lists the staged files with their status (git diff --cached --name-status),
+	// or holds the stat and patch of the commit being reworded or amended
+	Changes string
+	// Files are the paths touched by the changes
+	Files []string
+	// Branch is the current branch name, empty on a detached HEAD
+	Branch string
+	// Hint is the message the user passed with -m
+	Hint string
+	// Rules are the project-specific rules from the config and .aigcrules
+	Rules []string
+	// RecentCommits are the subjects of the latest commits, newest first
+	RecentCommits []string
+	// Tickets are the ticket IDs extracted from the branch name
+	Tickets []string
+	// Language is the language the commit message must be written in
+	Language string
+	// Convention is the name of the active commit convention
+	Convention string
+	// Format is the active convention's description of the message format
+	Format string
+	// Examples are few-shot messages learned from the repository's history
+	Examples []string
+}
+
+// sampleData is used to validate templates when they are loaded
+var sampleData = TemplateData{
+	Changes:       "M\tmain.go",
+	Files:         []string{"main.go"},
+	Branch:        "main",
+	Hint:          "hint",
+	Rules:         []string{"rule"},
+	RecentCommits: []string{"feat: add feature"},
+	Tickets:       []string{"PROJ-123"},
+	Language:      "English",
+	Convention:    "conventional",
+	Format:        "format",
+	Examples:      []string{"feat: add feature"},
+}
+
+// Templates holds the parsed prompt templates and where each one came from
+type Templates struct {
+	templates map[string]*template.Template
+	// Sources maps each template name to the file it was loaded from, or "built-in"
+	Sources map[string]string
+}
+
+// DefaultTemplates returns the compiled-in templates
+func DefaultTemplates() *Templates {
+	t := &Templates{templates: map[string]*template.Template{}, Sources: map[string]string{}}
+	for _, name := range TemplateNames {
+		t.templates[name] = template.Must(ParseTemplate(name, defaultTemplates[name]))
+		t.Sources[name] = "built-in"
+	}
+	return t
+}
+
+// DefaultTemplate returns the compiled-in text of a template
+func DefaultTemplate(name string) (string, error) {
+	text, ok := defaultTemplates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown template: %s. Must be one of: %s", name, strings.Join(TemplateNames, ", "))
+	}
+	return text, nil
+}
+
+// TemplateDirs returns the directories searched for templates, highest priority first
+func TemplateDirs(repoRoot, globalDir string) []string {
+	var dirs []string
+	if repoRoot != "" {
+		dirs = append(dirs, filepath.Join(repoRoot, ".aigc", "templates"))
+	}
+	return append(dirs, globalDir)
+}
+
+// LoadTemplates loads <name>.tmpl from the first directory that has it, falling back
+// to the built-in template. Every template is parsed and test-executed so mistakes
+// are reported before anything is sent to the provider.
+func LoadTemplates(dirs ...string) (*Templates, error) {
+	t := DefaultTemplates()
+
+	for _, name := range TemplateNames {
+		for _, dir := range dirs {
+			path := filepath.Join(dir, name+".tmpl")
+			data, err := os.ReadFile(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+
+			tmpl, err := ParseTemplate(name, string(data))
+			if err != nil {
+				return nil, fmt.Errorf("invalid template %s: %v", path, err)
+			}
+			t.templates[name] = tmpl
+			t.Sources[name] = path
+			break
+		}
+	}
+
+	return t, nil
+}
+
+// ParseTemplate parses a template and executes it against sample data to validate it
+func ParseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.Execute(&bytes.Buffer{}, sampleData); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+func (t *Templates) execute(name string, data TemplateData) (string, error) {
+	var b bytes.Buffer
+	if err := t.templates[name].Execute(&b, data); err != nil {
+		return "", fmt.Errorf("error rendering %s template: %v", name, err)
+	}
+	return b.String(), nil
+}
@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/dacsang97/aigc/internal/prompt"
 )
 
+// defaultAnthropicMaxTokens is sent when no max_tokens is configured, since the
+// Messages API requires it
+const defaultAnthropicMaxTokens = 1000
+
 type AnthropicProvider struct {
 	config  Config
 	baseURL string
-	prompt  *prompt.Generator
+	usage   Usage
 }
 
 func NewAnthropicProvider(config Config) (*AnthropicProvider, error) {
@@ -25,7 +30,6 @@ func NewAnthropicProvider(config Config) (*AnthropicProvider, error) {
 	return &AnthropicProvider{
 		config:  config,
 		baseURL: baseURL,
-		prompt:  prompt.New(),
 	}, nil
 }
 
@@ -35,31 +39,87 @@ type AnthropicMessage struct {
 }
 
 type AnthropicRequestBody struct {
-	Model     string             `json:"model"`
-	Messages  []AnthropicMessage `json:"messages"`
-	MaxTokens int                `json:"max_tokens"`
+	Model         string             `json:"model"`
+	System        string             `json:"system,omitempty"`
+	Messages      []AnthropicMessage `json:"messages"`
+	MaxTokens     int                `json:"max_tokens"`
+	Temperature   *float64           `json:"temperature,omitempty"`
+	TopP          *float64           `json:"top_p,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+	Tools         []AnthropicTool    `json:"tools,omitempty"`
+	ToolChoice    *AnthropicToolUse  `json:"tool_choice,omitempty"`
+}
+
+// AnthropicTool is a tool the model can call; forcing a call to it is how the
+// Messages API produces output that follows a schema
+type AnthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type AnthropicToolUse struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
 }
 
 type AnthropicResponse struct {
 	Content []struct {
-		Text string `json:"text"`
+		Type  string          `json:"type"`
+		Text  string          `json:"text"`
+		Input json.RawMessage `json:"input"`
 	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
 }
 
-func (p *AnthropicProvider) Generate(changes, userMessage string, rules []string) (string, error) {
-	messages := p.prompt.BuildMessages(changes, userMessage, rules)
-	anthropicMessages := make([]AnthropicMessage, len(messages))
-	for i, msg := range messages {
-		anthropicMessages[i] = AnthropicMessage{
+func (p *AnthropicProvider) Complete(messages []prompt.Message) (string, error) {
+	return p.complete(messages, nil)
+}
+
+// CompleteJSON forces a call to a tool whose input schema is schema and returns the
+// tool input
+func (p *AnthropicProvider) CompleteJSON(messages []prompt.Message, schema Schema) (string, error) {
+	return p.complete(messages, &AnthropicTool{
+		Name:        schema.Name,
+		Description: schema.Description,
+		InputSchema: schema.Schema,
+	})
+}
+
+func (p *AnthropicProvider) complete(messages []prompt.Message, tool *AnthropicTool) (string, error) {
+	// The Messages API takes the system prompt as a top-level field, not as a message
+	var system []string
+	anthropicMessages := make([]AnthropicMessage, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			system = append(system, msg.Content)
+			continue
+		}
+		anthropicMessages = append(anthropicMessages, AnthropicMessage{
 			Role:    msg.Role,
 			Content: msg.Content,
-		}
+		})
 	}
 
+	gen := p.config.generation()
 	reqBody := AnthropicRequestBody{
-		Model:     p.config.Model,
-		Messages:  anthropicMessages,
-		MaxTokens: 1000,
+		Model:         p.config.Model,
+		System:        strings.Join(system, "\n\n"),
+		Messages:      anthropicMessages,
+		MaxTokens:     gen.MaxTokens,
+		Temperature:   gen.Temperature,
+		TopP:          gen.TopP,
+		StopSequences: gen.Stop,
+	}
+	if reqBody.MaxTokens == 0 {
+		reqBody.MaxTokens = defaultAnthropicMaxTokens
+	}
+	if tool != nil {
+		reqBody.Tools = []AnthropicTool{*tool}
+		reqBody.ToolChoice = &AnthropicToolUse{Type: "tool", Name: tool.Name}
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -73,15 +133,14 @@ func (p *AnthropicProvider) Generate(changes, userMessage string, rules []string
 	}
 
 	if p.config.APIKey == "" {
-		return "", fmt.Errorf("API key not found. Please run 'aicm config' to set up your configuration")
+		return "", fmt.Errorf("API key not found. Please run 'aigc config' to set up your configuration")
 	}
 
 	req.Header.Set("x-api-key", p.config.APIKey)
 	req.Header.Set("anthropic-version", "2023-06-01")
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.config.httpClient().Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -91,15 +150,30 @@ func (p *AnthropicProvider) Generate(changes, userMessage string, rules []string
 	if err != nil {
 		return "", err
 	}
+	if err := checkStatus("Anthropic", resp, body); err != nil {
+		return "", err
+	}
 
 	var apiResp AnthropicResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return "", err
 	}
 
-	if len(apiResp.Content) == 0 {
-		return "", fmt.Errorf("no commit message generated")
+	p.usage = Usage{InputTokens: apiResp.Usage.InputTokens, OutputTokens: apiResp.Usage.OutputTokens}
+
+	// A forced tool call returns a tool_use block, otherwise the answer is the first
+	// text block
+	for _, content := range apiResp.Content {
+		if tool != nil && content.Type == "tool_use" {
+			return string(content.Input), nil
+		}
+		if tool == nil && content.Type == "text" {
+			return content.Text, nil
+		}
 	}
+	return "", fmt.Errorf("no commit message generated")
+}
 
-	return apiResp.Content[0].Text, nil
+func (p *AnthropicProvider) LastUsage() Usage {
+	return p.usage
 }
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/dacsang97/aigc/internal/cache"
+	"github.com/dacsang97/aigc/internal/prompt"
+)
+
+// CachedProvider serves repeated requests from a response cache and only calls the
+// wrapped provider on a miss. Entries are keyed by the provider, model, endpoint, API
+// style, generation settings, output format, scope and the rendered message list, so
+// any change to the prompt or to the content it was built from is a miss.
+type CachedProvider struct {
+	provider Provider
+	cache    *cache.Cache
+	config   Config
+	scope    string
+	hits     int
+}
+
+// WithCache wraps p so its responses are stored in c
+func WithCache(p Provider, c *cache.Cache, config Config) *CachedProvider {
+	return &CachedProvider{
+		provider: p,
+		cache:    c,
+		config:   config,
+	}
+}
+
+// SetScope adds an identifier of the content the prompts are built from, such as the
+// staged tree, to the cache key, for prompts that may not include all of it
+func (p *CachedProvider) SetScope(scope string) {
+	p.scope = scope
+}
+
+func (p *CachedProvider) Complete(messages []prompt.Message) (string, error) {
+	return p.complete(messages, "text", func() (string, error) {
+		return p.provider.Complete(messages)
+	})
+}
+
+func (p *CachedProvider) CompleteJSON(messages []prompt.Message, schema Schema) (string, error) {
+	return p.complete(messages, "json:"+schema.Name, func() (string, error) {
+		return CompleteJSON(p.provider, messages, schema)
+	})
+}
+
+// complete serves the response for messages in the given output format from the
+// cache, or calls the provider and stores its response
+func (p *CachedProvider) complete(messages []prompt.Message, format string, call func() (string, error)) (string, error) {
+	rendered, err := json.Marshal(messages)
+	if err != nil {
+		return "", err
+	}
+	generation, err := json.Marshal(p.config.Generation)
+	if err != nil {
+		return "", err
+	}
+	key := cache.Key(p.config.Provider, p.config.Model, p.config.Endpoint, p.config.APIStyle, string(generation), format, p.scope, string(rendered))
+
+	if entry, ok := p.cache.Get(key); ok {
+		p.hits++
+		return entry.Response, nil
+	}
+
+	response, err := call()
+	if err != nil {
+		return "", err
+	}
+
+	// A cache that cannot be written must not fail a generation that succeeded
+	p.cache.Put(key, cache.Entry{
+		Provider: p.config.Provider,
+		Model:    p.config.Model,
+		Response: response,
+	})
+	return response, nil
+}
+
+// Hits returns how many requests were served from the cache
+func (p *CachedProvider) Hits() int {
+	return p.hits
+}
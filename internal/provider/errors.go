@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody is how much of a response body without a recognizable error message is
+// included in the error
+const maxErrorBody = 500
+
+// checkStatus returns an error for a response that is not a 2xx, with the provider's
+// own error message when the body has one. Without it a rejected key, a rate limit or
+// a server error would be decoded as an empty answer.
+func checkStatus(name string, resp *http.Response, body []byte) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	message := errorMessage(body)
+	if message == "" {
+		message = strings.TrimSpace(string(body))
+		if len(message) > maxErrorBody {
+			message = message[:maxErrorBody] + "..."
+		}
+	}
+
+	err := fmt.Errorf("%s API error (%s): %s", name, resp.Status, message)
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		err = fmt.Errorf("%w. Check the API key with 'aigc config'", err)
+	}
+	return err
+}
+
+// errorMessage extracts the message of an error response. OpenAI, OpenRouter and
+// Anthropic send {"error": {"message": ...}}, while some OpenAI-compatible servers
+// such as Ollama send {"error": "..."}.
+func errorMessage(body []byte) string {
+	var resp struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Error) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(resp.Error, &text); err == nil {
+		return text
+	}
+
+	var detail struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(resp.Error, &detail); err == nil {
+		return detail.Message
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package provider
+
+import "strings"
+
+// Generation settings, named as in the config file
+const (
+	SettingTemperature = "temperature"
+	SettingMaxTokens   = "max_tokens"
+	SettingTopP        = "top_p"
+	SettingStop        = "stop"
+	// SettingReasoningEffort is how much reasoning models think before answering
+	SettingReasoningEffort = "reasoning_effort"
+)
+
+// Generation holds the sampling settings sent with every request. Nil and zero
+// values leave the provider's default in place.
+type Generation struct {
+	Temperature *float64
+	MaxTokens   int
+	TopP        *float64
+	Stop        []string
+	// ReasoningEffort is low, medium or high for reasoning models
+	ReasoningEffort string
+}
+
+// IgnoredSettings returns the generation settings that are set but not supported by
+// the provider, model and API style. Adapters leave them out of requests.
+func (c Config) IgnoredSettings() []string {
+	var ignored []string
+	for _, setting := range c.Generation.set() {
+		if !supports(c, setting) {
+			ignored = append(ignored, setting)
+		}
+	}
+	return ignored
+}
+
+// generation returns the generation settings without the ones the provider does not
+// support
+func (c Config) generation() Generation {
+	g := c.Generation
+	for _, setting := range c.IgnoredSettings() {
+		switch setting {
+		case SettingTemperature:
+			g.Temperature = nil
+		case SettingMaxTokens:
+			g.MaxTokens = 0
+		case SettingTopP:
+			g.TopP = nil
+		case SettingStop:
+			g.Stop = nil
+		case SettingReasoningEffort:
+			g.ReasoningEffort = ""
+		}
+	}
+	return g
+}
+
+func (g Generation) set() []string {
+	var set []string
+	if g.Temperature != nil {
+		set = append(set, SettingTemperature)
+	}
+	if g.MaxTokens > 0 {
+		set = append(set, SettingMaxTokens)
+	}
+	if g.TopP != nil {
+		set = append(set, SettingTopP)
+	}
+	if len(g.Stop) > 0 {
+		set = append(set, SettingStop)
+	}
+	if g.ReasoningEffort != "" {
+		set = append(set, SettingReasoningEffort)
+	}
+	return set
+}
+
+// supports reports whether a setting can be sent to the provider for the model.
+// OpenAI reasoning models reject sampling parameters and stop sequences, and only
+// they take a reasoning effort; the Responses API has no stop sequences. OpenRouter
+// drops unsupported parameters itself, so everything is passed through.
+func supports(c Config, setting string) bool {
+	switch c.Provider {
+	case "openai", "custom":
+		if isReasoningModel(c.Model) {
+			return setting == SettingMaxTokens || setting == SettingReasoningEffort
+		}
+		if setting == SettingReasoningEffort {
+			return false
+		}
+		return setting != SettingStop || c.APIStyle != APIStyleResponses
+	case "anthropic":
+		return setting != SettingReasoningEffort
+	}
+	return true
+}
+
+// isReasoningModel reports whether model is one of OpenAI's reasoning models
+func isReasoningModel(model string) bool {
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	for _, prefix := range []string{"o1", "o3", "o4", "gpt-5"} {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Model describes a model offered by a provider
+type Model struct {
+	ID            string `json:"id"`
+	Name          string `json:"name,omitempty"`
+	ContextLength int    `json:"context_length,omitempty"`
+	// InputPrice and OutputPrice are in US dollars per million tokens, for providers
+	// that report them
+	InputPrice  *float64 `json:"input_price,omitempty"`
+	OutputPrice *float64 `json:"output_price,omitempty"`
+}
+
+// ModelLister is implemented by providers that can list the models they offer
+type ModelLister interface {
+	ListModels() ([]Model, error)
+}
+
+// modelsURL derives the list-models endpoint from a completion endpoint, e.g.
+// https://api.openai.com/v1/chat/completions becomes https://api.openai.com/v1/models
+func modelsURL(baseURL string) string {
+	for _, suffix := range []string{"/chat/completions", "/responses", "/messages"} {
+		if base, ok := strings.CutSuffix(baseURL, suffix); ok {
+			return base + "/models"
+		}
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/models"
+}
+
+// getJSON sends a GET request and decodes a successful JSON response into out
+func getJSON(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s: %s", req.URL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
+
+// openAIModels is the list-models response of OpenAI-compatible APIs
+type openAIModels struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+func (m openAIModels) models() []Model {
+	models := make([]Model, len(m.Data))
+	for i, d := range m.Data {
+		models[i] = Model{ID: d.ID}
+	}
+	return models
+}
+
+// ListModels lists the models of /v1/models. Custom endpoints that do not serve it
+// are tried as an Ollama server, which lists its local models at /api/tags.
+func (p *OpenAIProvider) ListModels() ([]Model, error) {
+	models, err := p.listOpenAIModels()
+	if err == nil || p.config.Provider != "custom" {
+		return models, err
+	}
+	if ollama, ollamaErr := p.listOllamaModels(); ollamaErr == nil {
+		return ollama, nil
+	}
+	return nil, err
+}
+
+func (p *OpenAIProvider) listOpenAIModels() ([]Model, error) {
+	req, err := http.NewRequest("GET", modelsURL(p.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	var resp openAIModels
+	if err := getJSON(p.config.httpClient(), req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.models(), nil
+}
+
+func (p *OpenAIProvider) listOllamaModels() ([]Model, error) {
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", base.Scheme+"://"+base.Host+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := getJSON(p.config.httpClient(), req, &resp); err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, len(resp.Models))
+	for i, m := range resp.Models {
+		models[i] = Model{ID: m.Name}
+	}
+	return models, nil
+}
+
+// ListModels lists OpenRouter's models with their context length and prices. The
+// endpoint is public, so no API key is needed.
+func (p *OpenRouterProvider) ListModels() ([]Model, error) {
+	req, err := http.NewRequest("GET", modelsURL(p.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	var resp struct {
+		Data []struct {
+			ID            string `json:"id"`
+			Name          string `json:"name"`
+			ContextLength int    `json:"context_length"`
+			Pricing       struct {
+				Prompt     string `json:"prompt"`
+				Completion string `json:"completion"`
+			} `json:"pricing"`
+		} `json:"data"`
+	}
+	if err := getJSON(p.config.httpClient(), req, &resp); err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, len(resp.Data))
+	for i, d := range resp.Data {
+		models[i] = Model{
+			ID:            d.ID,
+			Name:          d.Name,
+			ContextLength: d.ContextLength,
+			InputPrice:    perMillion(d.Pricing.Prompt),
+			OutputPrice:   perMillion(d.Pricing.Completion),
+		}
+	}
+	return models, nil
+}
+
+// perMillion converts OpenRouter's per-token price to a price per million tokens
+func perMillion(perToken string) *float64 {
+	price, err := strconv.ParseFloat(perToken, 64)
+	if err != nil || price < 0 {
+		return nil
+	}
+	price *= 1e6
+	return &price
+}
+
+func (p *AnthropicProvider) ListModels() ([]Model, error) {
+	if p.config.APIKey == "" {
+		return nil, fmt.Errorf("API key not found. Please run 'aigc config' to set up your configuration")
+	}
+
+	req, err := http.NewRequest("GET", modelsURL(p.baseURL)+"?limit=1000", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", p.config.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	var resp struct {
+		Data []struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"display_name"`
+		} `json:"data"`
+	}
+	if err := getJSON(p.config.httpClient(), req, &resp); err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, len(resp.Data))
+	for i, d := range resp.Data {
+		models[i] = Model{ID: d.ID, Name: d.DisplayName}
+	}
+	return models, nil
+}
@@ -13,7 +13,7 @@ import (
 type OpenAIProvider struct {
 	config  Config
 	baseURL string
-	prompt  *prompt.Generator
+	usage   Usage
 }
 
 func NewOpenAIProvider(config Config) (*OpenAIProvider, error) {
@@ -25,13 +25,19 @@ func NewOpenAIProvider(config Config) (*OpenAIProvider, error) {
 	return &OpenAIProvider{
 		config:  config,
 		baseURL: baseURL,
-		prompt:  prompt.New(),
 	}, nil
 }
 
 type RequestBody struct {
-	Model    string           `json:"model"`
-	Messages []prompt.Message `json:"messages"`
+	Model               string           `json:"model"`
+	Messages            []prompt.Message `json:"messages"`
+	Temperature         *float64         `json:"temperature,omitempty"`
+	TopP                *float64         `json:"top_p,omitempty"`
+	MaxTokens           int              `json:"max_tokens,omitempty"`
+	MaxCompletionTokens int              `json:"max_completion_tokens,omitempty"`
+	Stop                []string         `json:"stop,omitempty"`
+	ReasoningEffort     string           `json:"reasoning_effort,omitempty"`
+	ResponseFormat      *ResponseFormat  `json:"response_format,omitempty"`
 }
 
 type Choice struct {
@@ -40,55 +46,140 @@ type Choice struct {
 	} `json:"message"`
 }
 
+// APIUsage is the usage object of OpenAI-compatible responses
+type APIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
 type APIResponse struct {
-	Choices []Choice `json:"choices"`
+	Choices []Choice  `json:"choices"`
+	Usage   *APIUsage `json:"usage"`
+}
+
+// usage converts the response's usage, which some compatible servers omit
+func (r APIResponse) usage() Usage {
+	if r.Usage == nil {
+		return Usage{}
+	}
+	return Usage{InputTokens: r.Usage.PromptTokens, OutputTokens: r.Usage.CompletionTokens}
+}
+
+func (p *OpenAIProvider) Complete(messages []prompt.Message) (string, error) {
+	return p.complete(messages, nil)
+}
+
+// CompleteJSON requests a strict json_schema response. Custom endpoints get the older
+// JSON mode, which more OpenAI-compatible servers understand; the schema is then only
+// enforced by the prompt.
+func (p *OpenAIProvider) CompleteJSON(messages []prompt.Message, schema Schema) (string, error) {
+	if p.config.Provider == "custom" {
+		return p.complete(messages, &ResponseFormat{Type: "json_object"})
+	}
+	return p.complete(messages, jsonSchemaFormat(schema))
 }
 
-func (p *OpenAIProvider) Generate(changes, userMessage string, rules []string) (string, error) {
-	messages := p.prompt.BuildMessages(changes, userMessage, rules)
+func (p *OpenAIProvider) complete(messages []prompt.Message, format *ResponseFormat) (string, error) {
+	if p.config.APIStyle == APIStyleResponses {
+		return p.completeResponses(messages, format)
+	}
 
+	gen := p.config.generation()
 	reqBody := RequestBody{
-		Model:    p.config.Model,
-		Messages: messages,
+		Model:           p.config.Model,
+		Messages:        p.roles(messages),
+		Temperature:     gen.Temperature,
+		TopP:            gen.TopP,
+		Stop:            gen.Stop,
+		ReasoningEffort: gen.ReasoningEffort,
+		ResponseFormat:  format,
+	}
+	// OpenAI deprecated max_tokens and reasoning models reject it, but many
+	// OpenAI-compatible servers only understand max_tokens
+	if p.config.Provider == "custom" && !isReasoningModel(p.config.Model) {
+		reqBody.MaxTokens = gen.MaxTokens
+	} else {
+		reqBody.MaxCompletionTokens = gen.MaxTokens
 	}
 
-	jsonData, err := json.Marshal(reqBody)
+	body, err := p.post(p.baseURL, reqBody)
 	if err != nil {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", p.baseURL, bytes.NewBuffer(jsonData))
-	if err != nil {
+	var apiResp APIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return "", err
 	}
 
+	if len(apiResp.Choices) == 0 {
+		return "", fmt.Errorf("no commit message generated")
+	}
+
+	p.usage = apiResp.usage()
+	return apiResp.Choices[0].Message.Content, nil
+}
+
+// roles sends system instructions as developer messages to reasoning models, which
+// reject the system role in some modes
+func (p *OpenAIProvider) roles(messages []prompt.Message) []prompt.Message {
+	if !isReasoningModel(p.config.Model) {
+		return messages
+	}
+
+	converted := make([]prompt.Message, len(messages))
+	for i, msg := range messages {
+		if msg.Role == "system" {
+			msg.Role = "developer"
+		}
+		converted[i] = msg
+	}
+	return converted
+}
+
+// post sends a JSON request body to url and returns the response body
+func (p *OpenAIProvider) post(url string, reqBody any) ([]byte, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
 	if p.config.APIKey == "" {
-		return "", fmt.Errorf("API key not found. Please run 'aicm config' to set up your configuration")
+		return nil, fmt.Errorf("API key not found. Please run 'aigc config' to set up your configuration")
 	}
 
 	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.config.httpClient().Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return "", err
+	if err := checkStatus(p.name(), resp, body); err != nil {
+		return nil, err
 	}
+	return body, nil
+}
 
-	if len(apiResp.Choices) == 0 {
-		return "", fmt.Errorf("no commit message generated")
+// name is how errors refer to the provider
+func (p *OpenAIProvider) name() string {
+	if p.config.Provider == "custom" {
+		return "Custom endpoint"
 	}
+	return "OpenAI"
+}
 
-	return apiResp.Choices[0].Message.Content, nil
+func (p *OpenAIProvider) LastUsage() Usage {
+	return p.usage
 }
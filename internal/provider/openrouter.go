@@ -10,10 +10,15 @@ import (
 	"github.com/dacsang97/aigc/internal/prompt"
 )
 
+const (
+	openRouterReferer = "https://github.com/dacsang97/aigc"
+	openRouterTitle   = "aigc"
+)
+
 type OpenRouterProvider struct {
 	config  Config
 	baseURL string
-	prompt  *prompt.Generator
+	usage   Usage
 }
 
 func NewOpenRouterProvider(config Config) (*OpenRouterProvider, error) {
@@ -25,23 +30,46 @@ func NewOpenRouterProvider(config Config) (*OpenRouterProvider, error) {
 	return &OpenRouterProvider{
 		config:  config,
 		baseURL: baseURL,
-		prompt:  prompt.New(),
 	}, nil
 }
 
 type OpenRouterRequestBody struct {
-	Stream   bool             `json:"stream"`
-	Model    string           `json:"model"`
-	Messages []prompt.Message `json:"messages"`
+	Stream         bool             `json:"stream"`
+	Model          string           `json:"model"`
+	Messages       []prompt.Message `json:"messages"`
+	Temperature    *float64         `json:"temperature,omitempty"`
+	TopP           *float64         `json:"top_p,omitempty"`
+	MaxTokens      int              `json:"max_tokens,omitempty"`
+	Stop           []string         `json:"stop,omitempty"`
+	ResponseFormat *ResponseFormat  `json:"response_format,omitempty"`
+	// Reasoning is OpenRouter's unified reasoning setting, translated for each model
+	Reasoning *ResponsesReasoning `json:"reasoning,omitempty"`
 }
 
-func (p *OpenRouterProvider) Generate(changes, userMessage string, rules []string) (string, error) {
-	messages := p.prompt.BuildMessages(changes, userMessage, rules)
+func (p *OpenRouterProvider) Complete(messages []prompt.Message) (string, error) {
+	return p.complete(messages, nil)
+}
+
+// CompleteJSON requests a strict json_schema response, which OpenRouter passes to
+// the models that support structured outputs
+func (p *OpenRouterProvider) CompleteJSON(messages []prompt.Message, schema Schema) (string, error) {
+	return p.complete(messages, jsonSchemaFormat(schema))
+}
 
+func (p *OpenRouterProvider) complete(messages []prompt.Message, format *ResponseFormat) (string, error) {
+	gen := p.config.generation()
 	reqBody := OpenRouterRequestBody{
-		Stream:   false,
-		Model:    p.config.Model,
-		Messages: messages,
+		Stream:         false,
+		Model:          p.config.Model,
+		Messages:       messages,
+		Temperature:    gen.Temperature,
+		TopP:           gen.TopP,
+		MaxTokens:      gen.MaxTokens,
+		Stop:           gen.Stop,
+		ResponseFormat: format,
+	}
+	if gen.ReasoningEffort != "" {
+		reqBody.Reasoning = &ResponsesReasoning{Effort: gen.ReasoningEffort}
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -55,14 +83,16 @@ func (p *OpenRouterProvider) Generate(changes, userMessage string, rules []strin
 	}
 
 	if p.config.APIKey == "" {
-		return "", fmt.Errorf("API key not found. Please run 'aicm config' to set up your configuration")
+		return "", fmt.Errorf("API key not found. Please run 'aigc config' to set up your configuration")
 	}
 
 	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
 	req.Header.Set("Content-Type", "application/json")
+	// Attribution shown in OpenRouter's rankings; configured http.headers replace them
+	req.Header.Set("HTTP-Referer", openRouterReferer)
+	req.Header.Set("X-Title", openRouterTitle)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.config.httpClient().Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -72,6 +102,9 @@ func (p *OpenRouterProvider) Generate(changes, userMessage string, rules []strin
 	if err != nil {
 		return "", err
 	}
+	if err := checkStatus("OpenRouter", resp, body); err != nil {
+		return "", err
+	}
 
 	var apiResp APIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
@@ -82,5 +115,10 @@ func (p *OpenRouterProvider) Generate(changes, userMessage string, rules []strin
 		return "", fmt.Errorf("no commit message generated")
 	}
 
+	p.usage = apiResp.usage()
 	return apiResp.Choices[0].Message.Content, nil
 }
+
+func (p *OpenRouterProvider) LastUsage() Usage {
+	return p.usage
+}
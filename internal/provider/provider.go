@@ -1,20 +1,57 @@
 package provider
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dacsang97/aigc/internal/prompt"
+)
 
 // Provider represents an AI completion provider interface
 type Provider interface {
-	Generate(changes, userMessage string, rules []string) (string, error)
+	// Complete sends an already built message list and returns the model's reply
+	Complete(messages []prompt.Message) (string, error)
+}
+
+// Usage is the number of tokens a request consumed, as reported by the provider
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// UsageReporter is implemented by providers that read token usage from their responses
+type UsageReporter interface {
+	// LastUsage returns the usage of the last successful Complete call
+	LastUsage() Usage
 }
 
 // Config represents the configuration for an AI provider
 type Config struct {
-	Provider string `yaml:"provider"` // "openai", "anthropic", "openrouter", or "custom"
-	Model    string `yaml:"model"`    // The model to use
-	APIKey   string `yaml:"api_key"`  // The API key for the provider
-	Endpoint string `yaml:"endpoint"` // Custom API endpoint URL (optional)
+	Provider string `yaml:"provider"`  // "openai", "anthropic", "openrouter", or "custom"
+	Model    string `yaml:"model"`     // The model to use
+	APIKey   string `yaml:"api_key"`   // The API key for the provider
+	Endpoint string `yaml:"endpoint"`  // Custom API endpoint URL (optional)
+	APIStyle string `yaml:"api_style"` // "chat" (default) or "responses" for OpenAI-compatible providers
+	// Generation holds the sampling settings sent with every request
+	Generation Generation `yaml:"generation"`
+	// HTTPClient sends the requests; nil uses http.DefaultClient
+	HTTPClient *http.Client `yaml:"-"`
 }
 
+// httpClient returns the client requests are sent with
+func (c Config) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// API styles of OpenAI-compatible providers
+const (
+	APIStyleChat      = "chat"
+	APIStyleResponses = "responses"
+)
+
 // ProviderConfig contains provider-specific configurations
 type ProviderConfig struct {
 	BaseURL string
@@ -37,15 +74,28 @@ var (
 
 // NewProvider creates a new AI provider based on the configuration
 func NewProvider(config Config) (Provider, error) {
+	switch config.APIStyle {
+	case "", APIStyleChat:
+	case APIStyleResponses:
+		if config.Provider != "openai" && config.Provider != "custom" {
+			return nil, fmt.Errorf("the %s API style is only supported by openai and custom providers", APIStyleResponses)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported API style: %s. Must be '%s' or '%s'", config.APIStyle, APIStyleChat, APIStyleResponses)
+	}
+
 	if config.Provider == "custom" {
 		if config.Endpoint == "" {
 			return nil, fmt.Errorf("endpoint URL is required for custom provider")
 		}
 		return NewOpenAIProvider(Config{
-			Provider: "custom",
-			Model:    config.Model,
-			APIKey:   config.APIKey,
-			Endpoint: config.Endpoint,
+			Provider:   "custom",
+			Model:      config.Model,
+			APIKey:     config.APIKey,
+			Endpoint:   config.Endpoint,
+			APIStyle:   config.APIStyle,
+			Generation: config.Generation,
+			HTTPClient: config.HTTPClient,
 		})
 	}
 
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/prompt"
+)
+
+// ResponsesRequestBody is a request to the OpenAI Responses API
+type ResponsesRequestBody struct {
+	Model           string              `json:"model"`
+	Input           []prompt.Message    `json:"input"`
+	Temperature     *float64            `json:"temperature,omitempty"`
+	TopP            *float64            `json:"top_p,omitempty"`
+	MaxOutputTokens int                 `json:"max_output_tokens,omitempty"`
+	Reasoning       *ResponsesReasoning `json:"reasoning,omitempty"`
+	Text            *ResponsesText      `json:"text,omitempty"`
+	// Store is always false so diffs are not kept on the server after the request
+	Store bool `json:"store"`
+}
+
+type ResponsesReasoning struct {
+	Effort string `json:"effort"`
+}
+
+type ResponsesText struct {
+	Format ResponsesFormat `json:"format"`
+}
+
+// ResponsesFormat is the output format of a Responses request. Unlike chat
+// completions, the json_schema fields are not nested.
+type ResponsesFormat struct {
+	Type        string         `json:"type"`
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty"`
+	Strict      bool           `json:"strict,omitempty"`
+}
+
+type ResponsesResponse struct {
+	Output []struct {
+		Type    string `json:"type"`
+		Content []struct {
+			Type    string `json:"type"`
+			Text    string `json:"text"`
+			Refusal string `json:"refusal"`
+		} `json:"content"`
+	} `json:"output"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// completeResponses sends the messages through the Responses API, which reasoning
+// models work best with
+func (p *OpenAIProvider) completeResponses(messages []prompt.Message, format *ResponseFormat) (string, error) {
+	gen := p.config.generation()
+	reqBody := ResponsesRequestBody{
+		Model:           p.config.Model,
+		Input:           p.roles(messages),
+		Temperature:     gen.Temperature,
+		TopP:            gen.TopP,
+		MaxOutputTokens: gen.MaxTokens,
+	}
+	if gen.ReasoningEffort != "" {
+		reqBody.Reasoning = &ResponsesReasoning{Effort: gen.ReasoningEffort}
+	}
+	if format != nil {
+		reqBody.Text = &ResponsesText{Format: ResponsesFormat{Type: format.Type}}
+		if schema := format.JSONSchema; schema != nil {
+			reqBody.Text.Format.Name = schema.Name
+			reqBody.Text.Format.Description = schema.Description
+			reqBody.Text.Format.Schema = schema.Schema
+			reqBody.Text.Format.Strict = schema.Strict
+		}
+	}
+
+	body, err := p.post(responsesURL(p.baseURL), reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	var apiResp ResponsesResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return "", err
+	}
+	if apiResp.Error != nil {
+		return "", fmt.Errorf("responses API error: %s", apiResp.Error.Message)
+	}
+
+	// Reasoning models emit reasoning items before the message; only the message's
+	// text parts are the answer
+	var text strings.Builder
+	for _, item := range apiResp.Output {
+		if item.Type != "message" {
+			continue
+		}
+		for _, content := range item.Content {
+			switch content.Type {
+			case "output_text":
+				text.WriteString(content.Text)
+			case "refusal":
+				return "", fmt.Errorf("the model refused to answer: %s", content.Refusal)
+			}
+		}
+	}
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no commit message generated")
+	}
+
+	p.usage = Usage{InputTokens: apiResp.Usage.InputTokens, OutputTokens: apiResp.Usage.OutputTokens}
+	return text.String(), nil
+}
+
+// responsesURL derives the Responses endpoint from a chat completions URL; any other
+// URL is assumed to be the Responses endpoint already
+func responsesURL(baseURL string) string {
+	if base, ok := strings.CutSuffix(baseURL, "/chat/completions"); ok {
+		return base + "/responses"
+	}
+	return baseURL
+}
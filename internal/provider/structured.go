@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"errors"
+
+	"github.com/dacsang97/aigc/internal/prompt"
+)
+
+// ErrStructuredUnsupported is returned by CompleteJSON for providers that cannot
+// constrain their output to a schema
+var ErrStructuredUnsupported = errors.New("structured output is not supported by this provider")
+
+// Schema is a JSON schema the response must follow
+type Schema struct {
+	Name        string
+	Description string
+	Schema      map[string]any
+}
+
+// StructuredProvider is implemented by providers that can constrain a response to a
+// JSON schema, through a JSON response format or a forced tool call
+type StructuredProvider interface {
+	// CompleteJSON sends the message list and returns the JSON object the model produced
+	CompleteJSON(messages []prompt.Message, schema Schema) (string, error)
+}
+
+// CompleteJSON asks p for a response following schema, or returns
+// ErrStructuredUnsupported if p cannot produce one
+func CompleteJSON(p Provider, messages []prompt.Message, schema Schema) (string, error) {
+	structured, ok := p.(StructuredProvider)
+	if !ok {
+		return "", ErrStructuredUnsupported
+	}
+	return structured.CompleteJSON(messages, schema)
+}
+
+// ResponseFormat is the response_format of OpenAI-compatible chat requests
+type ResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *JSONSchemaFormat `json:"json_schema,omitempty"`
+}
+
+// JSONSchemaFormat describes the schema of a json_schema response format
+type JSONSchemaFormat struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema"`
+	Strict      bool           `json:"strict"`
+}
+
+// jsonSchemaFormat returns a strict json_schema response format for schema
+func jsonSchemaFormat(schema Schema) *ResponseFormat {
+	return &ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &JSONSchemaFormat{
+			Name:        schema.Name,
+			Description: schema.Description,
+			Schema:      schema.Schema,
+			Strict:      true,
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dacsang97/aigc/internal/prompt"
+	"github.com/dacsang97/aigc/internal/usage"
+)
+
+// MeteredProvider records the token usage, latency and estimated cost of every
+// request made by the wrapped provider, and enforces the monthly budget before each
+// request.
+type MeteredProvider struct {
+	provider Provider
+	store    *usage.Store
+	prices   usage.PriceTable
+	budget   usage.Budget
+	config   Config
+	repo     string
+	// warnings receives the budget warning, printed once per process
+	warnings io.Writer
+	warned   bool
+}
+
+// WithUsage wraps p so its requests are recorded in store. Budget warnings are
+// written to warnings.
+func WithUsage(p Provider, store *usage.Store, prices usage.PriceTable, budget usage.Budget, config Config, warnings io.Writer) *MeteredProvider {
+	return &MeteredProvider{
+		provider: p,
+		store:    store,
+		prices:   prices,
+		budget:   budget,
+		config:   config,
+		warnings: warnings,
+	}
+}
+
+// SetRepository sets the repository requests are attributed to
+func (p *MeteredProvider) SetRepository(repo string) {
+	p.repo = repo
+}
+
+func (p *MeteredProvider) Complete(messages []prompt.Message) (string, error) {
+	return p.record(func() (string, error) {
+		return p.provider.Complete(messages)
+	})
+}
+
+func (p *MeteredProvider) CompleteJSON(messages []prompt.Message, schema Schema) (string, error) {
+	return p.record(func() (string, error) {
+		return CompleteJSON(p.provider, messages, schema)
+	})
+}
+
+// record checks the budget, makes the request and records its usage
+func (p *MeteredProvider) record(call func() (string, error)) (string, error) {
+	// An unreadable usage file only disables the budget, it never blocks a request
+	warning, err := p.budget.Check(p.store, time.Now())
+	if errors.Is(err, usage.ErrBudgetExceeded) {
+		return "", err
+	}
+	if warning != "" && !p.warned && p.warnings != nil {
+		fmt.Fprintf(p.warnings, "Warning: %s\n", warning)
+		p.warned = true
+	}
+
+	start := time.Now()
+	response, err := call()
+	if err != nil {
+		return "", err
+	}
+	latency := time.Since(start)
+
+	record := usage.Record{
+		Time:      start,
+		Repo:      p.repo,
+		Provider:  p.config.Provider,
+		Model:     p.config.Model,
+		LatencyMS: latency.Milliseconds(),
+	}
+	if reporter, ok := p.provider.(UsageReporter); ok {
+		u := reporter.LastUsage()
+		record.InputTokens = u.InputTokens
+		record.OutputTokens = u.OutputTokens
+	}
+	if price, ok := p.prices.Lookup(p.config.Model); ok {
+		record.Cost = price.Cost(record.InputTokens, record.OutputTokens)
+	} else {
+		record.Unpriced = true
+	}
+
+	// Failing to record usage must not fail a generation that succeeded
+	p.store.Append(record)
+	return response, nil
+}
@@ -0,0 +1,91 @@
+package release
+
+import (
+	"fmt"
+
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/semver"
+)
+
+// Versions parses the semantic version tags, ignoring the others
+func Versions(tags []string) []semver.Version {
+	var versions []semver.Version
+	for _, tag := range tags {
+		if v, err := semver.Parse(tag); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// LatestStable returns the highest version without a pre-release part
+func LatestStable(versions []semver.Version) (semver.Version, bool) {
+	var latest semver.Version
+	found := false
+	for _, v := range versions {
+		if v.Prerelease != "" {
+			continue
+		}
+		if !found || v.Compare(latest) > 0 {
+			latest, found = v, true
+		}
+	}
+	return latest, found
+}
+
+// LevelFor derives the bump from commits parsed with the convention: breaking changes are major,
+// feat is minor, fix and perf are patch. Other types do not warrant a release.
+// While the current major version is 0, breaking changes only bump the minor version.
+func LevelFor(commits []git.Commit, conv convention.Convention, current semver.Version) semver.Level {
+	level := semver.None
+	for _, c := range commits {
+		parsed, err := conv.Parse(c.Message)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case parsed.Breaking && current.Major == 0:
+			level = max(level, semver.Minor)
+		case parsed.Breaking:
+			return semver.Major
+		case parsed.Type == "feat":
+			level = max(level, semver.Minor)
+		case parsed.Type == "fix" || parsed.Type == "perf":
+			level = max(level, semver.Patch)
+		}
+	}
+	return level
+}
+
+// Next computes the version following the latest stable one. With a channel, the result
+// is the next pre-release of that channel, e.g. 1.3.0-beta.2 after 1.3.0-beta.1.
+func Next(versions []semver.Version, level semver.Level, channel string) (semver.Version, error) {
+	if level == semver.None {
+		return semver.Version{}, fmt.Errorf("no release-worthy commits (feat, fix, perf or breaking changes)")
+	}
+
+	stable, found := LatestStable(versions)
+	if !found {
+		stable = semver.Version{Prefix: "v"}
+	}
+
+	next := stable.Bump(level)
+	if channel == "" {
+		return next, nil
+	}
+
+	number := 0
+	for _, v := range versions {
+		if v.Core().Compare(next) != 0 {
+			continue
+		}
+		if c, n := v.Channel(); c == channel && n > number {
+			number = n
+		}
+	}
+
+	next.Prerelease = fmt.Sprintf("%s.%d", channel, number+1)
+	return next, nil
+}
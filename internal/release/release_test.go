@@ -0,0 +1,95 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/semver"
+)
+
+func TestLevelFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		messages []string
+		want     semver.Level
+	}{
+		{"nothing notable", "v1.0.0", []string{"docs: update readme", "chore: tidy"}, semver.None},
+		{"fix is a patch", "v1.0.0", []string{"fix: handle nil", "docs: note"}, semver.Patch},
+		{"perf is a patch", "v1.0.0", []string{"perf: cache lookups"}, semver.Patch},
+		{"feat is a minor", "v1.0.0", []string{"fix: handle nil", "feat: add flag"}, semver.Minor},
+		{"bang is a major", "v1.0.0", []string{"feat: add flag", "refactor!: drop v1 api"}, semver.Major},
+		{"footer is a major", "v1.0.0", []string{"fix: rename\n\nBREAKING CHANGE: Run takes a context"}, semver.Major},
+		{"breaking is a minor before 1.0", "v0.3.0", []string{"feat!: drop v1 api", "fix: handle nil"}, semver.Minor},
+		{"feat is a minor before 1.0", "v0.3.0", []string{"feat: add flag"}, semver.Minor},
+		{"fix is a patch before 1.0", "v0.3.0", []string{"fix: handle nil"}, semver.Patch},
+		{"other conventions are skipped", "v1.0.0", []string{"Add flag", "wip"}, semver.None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := semver.Parse(tt.current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var commits []git.Commit
+			for _, m := range tt.messages {
+				commits = append(commits, git.Commit{Message: m})
+			}
+
+			if got := LevelFor(commits, convention.Default(), current); got != tt.want {
+				t.Errorf("LevelFor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		level   semver.Level
+		channel string
+		want    string
+	}{
+		{"first release", nil, semver.Minor, "", "v0.1.0"},
+		{"patch", []string{"v1.2.3", "v1.2.2"}, semver.Patch, "", "v1.2.4"},
+		{"ignores non-semver tags", []string{"latest", "v1.2.3"}, semver.Minor, "", "v1.3.0"},
+		{"ignores pre-releases for the base", []string{"v1.2.3", "v1.3.0-beta.1"}, semver.Minor, "", "v1.3.0"},
+		{"build metadata", []string{"v1.2.3+meta"}, semver.Patch, "", "v1.2.4"},
+		{"0.x major", []string{"0.4.1"}, semver.Major, "", "1.0.0"},
+		{"first pre-release", []string{"v1.2.3"}, semver.Minor, "beta", "v1.3.0-beta.1"},
+		{"next pre-release", []string{"v1.2.3", "v1.3.0-beta.1", "v1.3.0-beta.2"}, semver.Minor, "beta", "v1.3.0-beta.3"},
+		{"channels count separately", []string{"v1.2.3", "v1.3.0-beta.2"}, semver.Minor, "rc", "v1.3.0-rc.1"},
+		{"other versions' pre-releases", []string{"v1.2.3", "v1.2.4-beta.5"}, semver.Minor, "beta", "v1.3.0-beta.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Next(Versions(tt.tags), tt.level, tt.channel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Next() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextWithoutLevel(t *testing.T) {
+	if v, err := Next(Versions([]string{"v1.0.0"}), semver.None, ""); err == nil {
+		t.Errorf("Next() = %s, want an error when nothing warrants a release", v)
+	}
+}
+
+func TestLatestStableKeepsTagName(t *testing.T) {
+	stable, found := LatestStable(Versions([]string{"v1.2.2", "v1.2.3+meta", "v1.3.0-rc.1"}))
+	if !found {
+		t.Fatal("no stable version found")
+	}
+	if got := stable.String(); got != "v1.2.3+meta" {
+		t.Errorf("String() = %q, want the tag name v1.2.3+meta", got)
+	}
+}
@@ -0,0 +1,180 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionPattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// Level is the part of a version a release bumps
+type Level int
+
+const (
+	None Level = iota
+	Patch
+	Minor
+	Major
+)
+
+func (l Level) String() string {
+	switch l {
+	case Patch:
+		return "patch"
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// ParseLevel parses "major", "minor" or "patch"
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "major":
+		return Major, nil
+	case "minor":
+		return Minor, nil
+	case "patch":
+		return Patch, nil
+	default:
+		return None, fmt.Errorf("invalid bump level: %s. Must be 'major', 'minor' or 'patch'", s)
+	}
+}
+
+// Version is a semantic version, optionally written with a "v" prefix
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	// Build is the build metadata after "+"; it is kept so String returns the original
+	// tag name, but ignored for precedence and dropped by Bump
+	Build string
+}
+
+// Parse parses a tag such as "v1.2.3", "1.2.3-beta.1" or "v1.2.3+build.5"
+func Parse(s string) (Version, error) {
+	match := versionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return Version{}, fmt.Errorf("not a semantic version: %s", s)
+	}
+
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+
+	return Version{
+		Prefix:     match[1],
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: match[5],
+		Build:      match[6],
+	}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Core returns the version without its pre-release part and build metadata
+func (v Version) Core() Version {
+	v.Prerelease = ""
+	v.Build = ""
+	return v
+}
+
+// Bump returns the next stable version for the given level
+func (v Version) Bump(level Level) Version {
+	next := v.Core()
+	switch level {
+	case Major:
+		next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+	case Minor:
+		next.Minor, next.Patch = v.Minor+1, 0
+	case Patch:
+		next.Patch = v.Patch + 1
+	}
+	return next
+}
+
+// Channel splits a pre-release such as "beta.3" into its channel and number
+func (v Version) Channel() (string, int) {
+	if v.Prerelease == "" {
+		return "", 0
+	}
+	i := strings.LastIndex(v.Prerelease, ".")
+	if i < 0 {
+		return v.Prerelease, 0
+	}
+	n, err := strconv.Atoi(v.Prerelease[i+1:])
+	if err != nil {
+		return v.Prerelease, 0
+	}
+	return v.Prerelease[:i], n
+}
+
+// Compare returns -1, 0 or 1 following semver precedence, which ignores build metadata
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+func compareIdentifier(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(na - nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
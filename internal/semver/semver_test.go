@@ -0,0 +1,125 @@
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Version
+		err  bool
+	}{
+		{tag: "v1.2.3", want: Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3}},
+		{tag: "0.10.0", want: Version{Minor: 10}},
+		{tag: "v2.0.0-beta.1", want: Version{Prefix: "v", Major: 2, Prerelease: "beta.1"}},
+		{tag: "v1.2.3+meta", want: Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3, Build: "meta"}},
+		{tag: "1.0.0-rc.1+build.7", want: Version{Major: 1, Prerelease: "rc.1", Build: "build.7"}},
+		{tag: "v1.2", err: true},
+		{tag: "v01.2.3", err: true},
+		{tag: "release-1.2.3", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := Parse(tt.tag)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want an error", tt.tag, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.tag, got, tt.want)
+			}
+			if got.String() != tt.tag {
+				t.Errorf("String() = %q, want the original tag %q", got.String(), tt.tag)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.3.0", "1.2.9", 1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"1.0.0-rc.1+meta", "1.0.0-rc.1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+			if got := a.Compare(b); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+			if got := b.Compare(a); got != -tt.want {
+				t.Errorf("reverse Compare() = %d, want %d", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		version string
+		level   Level
+		want    string
+	}{
+		{"v1.2.3", Patch, "v1.2.4"},
+		{"v1.2.3", Minor, "v1.3.0"},
+		{"v1.2.3", Major, "v2.0.0"},
+		{"v1.2.3", None, "v1.2.3"},
+		{"0.4.1", Minor, "0.5.0"},
+		{"v1.3.0-beta.2", Minor, "v1.4.0"},
+		{"v1.2.3+meta", Patch, "v1.2.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.level.String(), func(t *testing.T) {
+			v, err := Parse(tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Bump(tt.level).String(); got != tt.want {
+				t.Errorf("Bump(%s) = %s, want %s", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannel(t *testing.T) {
+	tests := []struct {
+		version string
+		channel string
+		number  int
+	}{
+		{"1.0.0", "", 0},
+		{"1.0.0-beta.3", "beta", 3},
+		{"1.0.0-rc", "rc", 0},
+		{"1.0.0-pre.release", "pre.release", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, _ := Parse(tt.version)
+			channel, number := v.Channel()
+			if channel != tt.channel || number != tt.number {
+				t.Errorf("Channel() = %q, %d, want %q, %d", channel, number, tt.channel, tt.number)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package split
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/commit"
+	"github.com/dacsang97/aigc/internal/git"
+	"github.com/dacsang97/aigc/internal/prompt"
+)
+
+// Group is one commit of a split: a message and the hunks it contains
+type Group struct {
+	Message string
+	Hunks   []git.Hunk
+}
+
+type planResponse struct {
+	Commits []struct {
+		Message string `json:"message"`
+		Hunks   []int  `json:"hunks"`
+	} `json:"commits"`
+}
+
+// Plan asks the model to group hunks into commits. Hunks the model leaves out are
+// collected into a trailing group whose message is generated separately.
+func Plan(generator *commit.Generator, hunks []git.Hunk, rules []string) ([]Group, error) {
+	messages := generator.Prompt().BuildSplitMessages(describeHunks(hunks), rules)
+
+	reply, err := generator.Complete(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp planResponse
+	if err := json.Unmarshal([]byte(prompt.ExtractJSON(reply)), &resp); err != nil {
+		return nil, fmt.Errorf("could not parse split proposal: %v", err)
+	}
+
+	byID := make(map[int]git.Hunk, len(hunks))
+	for _, h := range hunks {
+		byID[h.ID] = h
+	}
+
+	assigned := map[int]bool{}
+	var groups []Group
+	for _, c := range resp.Commits {
+		group := Group{Message: strings.TrimSpace(c.Message)}
+		for _, id := range c.Hunks {
+			h, ok := byID[id]
+			if !ok || assigned[id] {
+				continue
+			}
+			assigned[id] = true
+			group.Hunks = append(group.Hunks, h)
+		}
+		if len(group.Hunks) == 0 || group.Message == "" {
+			continue
+		}
+		// Keep hunks of the same file contiguous so each file header is written once
+		sort.Slice(group.Hunks, func(i, j int) bool { return group.Hunks[i].ID < group.Hunks[j].ID })
+		group.Message = generator.AddTickets(group.Message)
+		groups = append(groups, group)
+	}
+
+	var rest []git.Hunk
+	for _, h := range hunks {
+		if !assigned[h.ID] {
+			rest = append(rest, h)
+		}
+	}
+
+	if len(rest) > 0 {
+		message, err := generator.Generate(git.BuildPatch(rest), "", rules)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, Group{Message: strings.TrimSpace(message), Hunks: rest})
+	}
+
+	return groups, nil
+}
+
+// Apply creates one commit per group, in order. The index is rebuilt from HEAD
+// hunk by hunk with `git apply --cached`, so the working tree is never touched.
+// If anything fails, HEAD and the original index are restored.
+func Apply(gitClient git.Client, groups []Group) (err error) {
+	originalIndex, err := gitClient.SaveIndex()
+	if err != nil {
+		return err
+	}
+
+	// An unborn branch has no HEAD yet; rolling back then means deleting the branch again
+	originalHead, _ := gitClient.ResolveCommit("HEAD")
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		if resetErr := gitClient.ResetHead(originalHead); resetErr != nil {
+			err = fmt.Errorf("%v (rollback failed: %v)", err, resetErr)
+			return
+		}
+		if restoreErr := gitClient.RestoreIndex(originalIndex); restoreErr != nil {
+			err = fmt.Errorf("%v (rollback failed: %v)", err, restoreErr)
+		}
+	}()
+
+	if err := gitClient.ResetIndex(); err != nil {
+		return err
+	}
+
+	for i, group := range groups {
+		if err := gitClient.ApplyToIndex(git.BuildPatch(group.Hunks)); err != nil {
+			return fmt.Errorf("commit %d: %v", i+1, err)
+		}
+		if err := gitClient.Commit(group.Message); err != nil {
+			return fmt.Errorf("commit %d: %v", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func describeHunks(hunks []git.Hunk) string {
+	var b strings.Builder
+	for _, h := range hunks {
+		fmt.Fprintf(&b, "### Hunk %d: %s\n%s\n", h.ID, h.File, h.Summary())
+	}
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package split
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+// newRepo creates a repository with one committed file of 30 lines, then stages
+// edits to lines 2 and 28 so the staged diff has two hunks in the same file
+func newRepo(t *testing.T) (*git.Repository, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run(t, dir, "init", "--quiet")
+	run(t, dir, "config", "user.name", "Split")
+	run(t, dir, "config", "user.email", "split@example.com")
+	run(t, dir, "config", "commit.gpgsign", "false")
+
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	writeLines(t, dir, lines)
+	run(t, dir, "add", ".")
+	run(t, dir, "commit", "--quiet", "-m", "initial")
+
+	lines[1] = "line 2 changed"
+	lines[27] = "line 28 changed"
+	writeLines(t, dir, lines)
+	run(t, dir, "add", ".")
+
+	repo, err := git.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo, dir
+}
+
+func writeLines(t *testing.T, dir string, lines []string) {
+	t.Helper()
+	content := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func run(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func stagedHunks(t *testing.T, repo *git.Repository) []git.Hunk {
+	t.Helper()
+	hunks, err := repo.GetStagedHunks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hunks) != 2 || hunks[0].File != "file.txt" || hunks[1].File != "file.txt" {
+		t.Fatalf("GetStagedHunks() = %+v, want two hunks of file.txt", hunks)
+	}
+	return hunks
+}
+
+func TestApplySplitsHunksOfOneFile(t *testing.T) {
+	repo, dir := newRepo(t)
+	hunks := stagedHunks(t, repo)
+
+	groups := []Group{
+		{Message: "fix: change line 2", Hunks: hunks[:1]},
+		{Message: "fix: change line 28", Hunks: hunks[1:]},
+	}
+	if err := Apply(repo, groups); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := run(t, dir, "log", "--format=%s"); got != "fix: change line 28\nfix: change line 2\ninitial" {
+		t.Errorf("log = %q", got)
+	}
+
+	first := run(t, dir, "show", "--format=", "HEAD~1")
+	if !strings.Contains(first, "+line 2 changed") || strings.Contains(first, "line 28 changed") {
+		t.Errorf("first commit should only change line 2:\n%s", first)
+	}
+	second := run(t, dir, "show", "--format=", "HEAD")
+	if !strings.Contains(second, "+line 28 changed") || strings.Contains(second, "line 2 changed") {
+		t.Errorf("second commit should only change line 28:\n%s", second)
+	}
+
+	if status := run(t, dir, "status", "--porcelain"); status != "" {
+		t.Errorf("working tree and index should match HEAD, status = %q", status)
+	}
+}
+
+func TestApplyRestoresIndexOnFailure(t *testing.T) {
+	repo, dir := newRepo(t)
+	hunks := stagedHunks(t, repo)
+
+	head := run(t, dir, "rev-parse", "HEAD")
+	index := run(t, dir, "write-tree")
+
+	// The second group repeats the first hunk, which no longer applies once committed
+	groups := []Group{
+		{Message: "fix: change line 2", Hunks: hunks[:1]},
+		{Message: "fix: change line 2 again", Hunks: hunks[:1]},
+	}
+	err := Apply(repo, groups)
+	if err == nil {
+		t.Fatal("Apply succeeded, want an error from the second group")
+	}
+	if !strings.HasPrefix(err.Error(), "commit 2:") {
+		t.Errorf("error = %v, want it to name commit 2", err)
+	}
+
+	if got := run(t, dir, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD = %s, want it rolled back to %s", got, head)
+	}
+	if got := run(t, dir, "write-tree"); got != index {
+		t.Errorf("index tree = %s, want the original %s", got, index)
+	}
+}
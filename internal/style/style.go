@@ -0,0 +1,134 @@
+package style
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dacsang97/aigc/internal/convention"
+	"github.com/dacsang97/aigc/internal/git"
+)
+
+var lowValuePattern = regexp.MustCompile(`(?i)^(wip|fix|fixes|fixed|update|updates|updated|changes|misc|tmp|test|typo|minor|cleanup|\.+)\.?$`)
+
+// Profile is the commit style learned from a repository's history
+type Profile struct {
+	// Convention is the name of the dominant convention
+	Convention string
+	// Examples are the best-scoring recent messages that follow the convention
+	Examples []string
+}
+
+// Learn detects the dominant convention of the commits and picks up to limit of the
+// best-scoring messages that follow it as few-shot examples
+func Learn(commits []git.Commit, limit int) Profile {
+	name := Detect(commits)
+
+	type candidate struct {
+		message string
+		score   int
+	}
+
+	seen := map[string]bool{}
+	var candidates []candidate
+	for _, c := range commits {
+		if Classify(c.Subject) != name || seen[c.Subject] {
+			continue
+		}
+		seen[c.Subject] = true
+
+		if score := Score(c.Message); score > 0 {
+			candidates = append(candidates, candidate{message: c.Message, score: score})
+		}
+	}
+
+	// Stable sort keeps newer commits first among equal scores
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	profile := Profile{Convention: name}
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		profile.Examples = append(profile.Examples, candidates[i].message)
+	}
+	return profile
+}
+
+// Detect returns the name of the convention followed by most of the commits. A
+// convention must cover at least 40% of the sample to win, otherwise the history is
+// treated as plain.
+func Detect(commits []git.Commit) string {
+	if len(commits) == 0 {
+		return convention.Default().Name()
+	}
+
+	counts := map[string]int{}
+	best, bestCount := "plain", 0
+	for _, c := range commits {
+		name := Classify(c.Subject)
+		counts[name]++
+		if name != "plain" && counts[name] > bestCount {
+			best, bestCount = name, counts[name]
+		}
+	}
+
+	if bestCount*10 < len(commits)*4 {
+		return "plain"
+	}
+	return best
+}
+
+// Classify returns the name of the convention a single subject line follows
+func Classify(subject string) string {
+	return convention.Detect(subject).Name()
+}
+
+// Score rates how good an example a message is. Messages scoring zero or less
+// (merges, reverts, "wip", one-word subjects, ...) are never used as examples.
+func Score(message string) int {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	subject = strings.TrimSpace(subject)
+	body = strings.TrimSpace(body)
+
+	lower := strings.ToLower(subject)
+	if strings.HasPrefix(lower, "merge ") || strings.HasPrefix(lower, "revert ") || strings.HasPrefix(lower, "fixup!") || strings.HasPrefix(lower, "squash!") {
+		return 0
+	}
+	if lowValuePattern.MatchString(subject) || len(strings.Fields(subject)) < 3 {
+		return 0
+	}
+
+	score := 2
+	switch n := len(subject); {
+	case n >= 20 && n <= 72:
+		score += 2
+	case n > 100:
+		score--
+	}
+	if !strings.HasSuffix(subject, ".") {
+		score++
+	}
+	if body != "" {
+		score += 2
+		if len(body) > 1000 {
+			score -= 2
+		}
+	}
+	return score
+}
+
+// LearnFromHistory learns the style from up to sample of the most recent commits.
+// Zero values fall back to 50 sampled commits and 3 examples.
+func LearnFromHistory(gitClient git.Client, sample, examples int) (Profile, error) {
+	if sample <= 0 {
+		sample = 50
+	}
+	if examples <= 0 {
+		examples = 3
+	}
+
+	commits, err := gitClient.RecentCommits(sample)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	return Learn(commits, examples), nil
+}
@@ -0,0 +1,117 @@
+// Package transport builds the HTTP client providers share, so gateways, proxies and
+// private certificate authorities are configured once for every provider.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Config describes how requests reach the provider
+type Config struct {
+	// Headers are added to every request, replacing headers of the same name
+	Headers map[string]string
+	// Proxy is the proxy URL; when empty HTTPS_PROXY, HTTP_PROXY and NO_PROXY apply
+	Proxy string
+	// CACert is a PEM bundle trusted in addition to the system roots
+	CACert string
+	// ClientCert and ClientKey are a PEM certificate and key for mutual TLS
+	ClientCert string
+	ClientKey  string
+	// InsecureSkipVerify disables certificate verification, for local testing only
+	InsecureSkipVerify bool
+}
+
+// NewClient returns an HTTP client configured by cfg
+func NewClient(cfg Config) (*http.Client, error) {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		base.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	base.TLSClientConfig = tlsConfig
+
+	var rt http.RoundTripper = base
+	if len(cfg.Headers) > 0 {
+		rt = &headerTransport{base: base, headers: cfg.Headers}
+	}
+	return &http.Client{Transport: rt}, nil
+}
+
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACert != "" {
+		pem, err := os.ReadFile(expandHome(cfg.CACert))
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		if cfg.ClientCert == "" || cfg.ClientKey == "" {
+			return nil, fmt.Errorf("mutual TLS needs both a client certificate and a key")
+		}
+		cert, err := tls.LoadX509KeyPair(expandHome(cfg.ClientCert), expandHome(cfg.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// headerTransport sets the configured headers on every request
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+	return t.base.RoundTrip(req)
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
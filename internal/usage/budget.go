@@ -0,0 +1,53 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Budget actions
+const (
+	BudgetWarn  = "warn"
+	BudgetBlock = "block"
+)
+
+// ErrBudgetExceeded is returned for requests made after a blocking budget is spent
+var ErrBudgetExceeded = errors.New("monthly budget exceeded")
+
+// Budget limits the estimated monthly spend
+type Budget struct {
+	Monthly float64 // Limit in US dollars; 0 means no limit
+	Action  string  // warn (default) or block
+}
+
+// Check compares the spend of the current month with the budget. It returns a warning
+// once the budget is spent, or ErrBudgetExceeded when the action is block.
+func (b Budget) Check(store *Store, now time.Time) (string, error) {
+	if b.Monthly <= 0 {
+		return "", nil
+	}
+
+	spent, err := store.MonthToDate(now)
+	if err != nil {
+		return "", err
+	}
+	if spent < b.Monthly {
+		return "", nil
+	}
+
+	if b.Action == BudgetBlock {
+		return "", fmt.Errorf("%w: $%.2f of $%.2f spent this month; raise usage.budget or set usage.budget_action to warn", ErrBudgetExceeded, spent, b.Monthly)
+	}
+	return fmt.Sprintf("the monthly budget of $%.2f is spent ($%.2f so far)", b.Monthly, spent), nil
+}
+
+// ValidateAction reports whether action is a known budget action
+func ValidateAction(action string) error {
+	switch action {
+	case "", BudgetWarn, BudgetBlock:
+		return nil
+	default:
+		return fmt.Errorf("unknown budget action: %s. Must be '%s' or '%s'", action, BudgetWarn, BudgetBlock)
+	}
+}
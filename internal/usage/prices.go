@@ -0,0 +1,86 @@
+package usage
+
+import "strings"
+
+// Price is the cost of a model in US dollars per million tokens
+type Price struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// Cost returns the cost of a request with the given token counts
+func (p Price) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
+
+// DefaultPrices are the list prices of common models. Model IDs are matched by prefix,
+// so dated snapshots such as gpt-4o-2024-08-06 use the price of gpt-4o.
+var DefaultPrices = map[string]Price{
+	"gpt-4o":              {Input: 2.50, Output: 10.00},
+	"gpt-4o-mini":         {Input: 0.15, Output: 0.60},
+	"gpt-4.1":             {Input: 2.00, Output: 8.00},
+	"gpt-4.1-mini":        {Input: 0.40, Output: 1.60},
+	"gpt-4.1-nano":        {Input: 0.10, Output: 0.40},
+	"gpt-4-turbo":         {Input: 10.00, Output: 30.00},
+	"gpt-3.5-turbo":       {Input: 0.50, Output: 1.50},
+	"o1":                  {Input: 15.00, Output: 60.00},
+	"o1-mini":             {Input: 1.10, Output: 4.40},
+	"o3-mini":             {Input: 1.10, Output: 4.40},
+	"o4-mini":             {Input: 1.10, Output: 4.40},
+	"claude-3-haiku":      {Input: 0.25, Output: 1.25},
+	"claude-3-5-haiku":    {Input: 0.80, Output: 4.00},
+	"claude-3-5-sonnet":   {Input: 3.00, Output: 15.00},
+	"claude-3-7-sonnet":   {Input: 3.00, Output: 15.00},
+	"claude-sonnet-4":     {Input: 3.00, Output: 15.00},
+	"claude-3-opus":       {Input: 15.00, Output: 75.00},
+	"claude-opus-4":       {Input: 15.00, Output: 75.00},
+	"gemini-flash-1.5-8b": {Input: 0.0375, Output: 0.15},
+	"gemini-flash-1.5":    {Input: 0.075, Output: 0.30},
+	"gemini-2.0-flash":    {Input: 0.10, Output: 0.40},
+}
+
+// PriceTable looks up model prices, with configured prices taking precedence over the
+// defaults
+type PriceTable map[string]Price
+
+// NewPriceTable returns the default prices merged with overrides
+func NewPriceTable(overrides map[string]Price) PriceTable {
+	table := make(PriceTable, len(DefaultPrices)+len(overrides))
+	for model, price := range DefaultPrices {
+		table[model] = price
+	}
+	for model, price := range overrides {
+		table[model] = price
+	}
+	return table
+}
+
+// Lookup returns the price of model. An exact match wins, then the longest prefix;
+// the vendor part of OpenRouter IDs such as openai/gpt-4o is ignored when the full
+// ID is not in the table.
+func (t PriceTable) Lookup(model string) (Price, bool) {
+	if price, ok := t.lookup(model); ok {
+		return price, true
+	}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		return t.lookup(model[i+1:])
+	}
+	return Price{}, false
+}
+
+func (t PriceTable) lookup(model string) (Price, bool) {
+	if price, ok := t[model]; ok {
+		return price, true
+	}
+
+	best := ""
+	for prefix := range t {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return Price{}, false
+	}
+	return t[best], true
+}
@@ -0,0 +1,97 @@
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Groupings a report can be broken down by
+var Groupings = []string{"model", "repo", "provider", "day"}
+
+// Row sums the records sharing a key
+type Row struct {
+	Key          string
+	Calls        int
+	InputTokens  int
+	OutputTokens int
+	Cost         float64
+	// Unpriced counts the calls whose model had no price, so Cost is a lower bound
+	Unpriced int
+	latency  time.Duration
+}
+
+// AverageLatency returns the mean latency of the calls
+func (r Row) AverageLatency() time.Duration {
+	if r.Calls == 0 {
+		return 0
+	}
+	return r.latency / time.Duration(r.Calls)
+}
+
+func (r *Row) add(record Record) {
+	r.Calls++
+	r.InputTokens += record.InputTokens
+	r.OutputTokens += record.OutputTokens
+	r.Cost += record.Cost
+	r.latency += time.Duration(record.LatencyMS) * time.Millisecond
+	if record.Unpriced {
+		r.Unpriced++
+	}
+}
+
+// Summarize groups records by one of Groupings and returns one row per key, most
+// expensive first, followed by the total
+func Summarize(records []Record, by string) ([]Row, Row, error) {
+	key, err := groupKey(by)
+	if err != nil {
+		return nil, Row{}, err
+	}
+
+	total := Row{Key: "Total"}
+	rows := map[string]*Row{}
+	for _, record := range records {
+		k := key(record)
+		if k == "" {
+			k = "(unknown)"
+		}
+		if rows[k] == nil {
+			rows[k] = &Row{Key: k}
+		}
+		rows[k].add(record)
+		total.add(record)
+	}
+
+	result := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if by == "day" {
+			return result[i].Key < result[j].Key
+		}
+		if result[i].Cost != result[j].Cost {
+			return result[i].Cost > result[j].Cost
+		}
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result, total, nil
+}
+
+func groupKey(by string) (func(Record) string, error) {
+	switch by {
+	case "", "model":
+		return func(r Record) string { return r.Model }, nil
+	case "repo":
+		return func(r Record) string { return r.Repo }, nil
+	case "provider":
+		return func(r Record) string { return r.Provider }, nil
+	case "day":
+		return func(r Record) string { return r.Time.Local().Format(time.DateOnly) }, nil
+	default:
+		return nil, fmt.Errorf("unknown grouping: %s. Must be one of %v", by, Groupings)
+	}
+}
@@ -0,0 +1,136 @@
+// Package usage records the tokens and estimated cost of every provider request and
+// summarizes them into reports.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is one provider request
+type Record struct {
+	Time         time.Time `json:"time"`
+	Repo         string    `json:"repo,omitempty"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	LatencyMS    int64     `json:"latency_ms"`
+	Cost         float64   `json:"cost"`
+	// Unpriced is set when the model is missing from the price table, so Cost is 0
+	Unpriced bool `json:"unpriced,omitempty"`
+}
+
+// Store keeps records in a JSON Lines file, one record per line
+type Store struct {
+	path string
+}
+
+// NewStore returns a store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file records are stored in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append adds a record to the end of the file
+func (s *Store) Append(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("error creating usage directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	// A single write keeps concurrent appends from interleaving within a line
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Since returns the records made at or after t, oldest first. Lines that cannot be
+// parsed are skipped so one corrupt line does not hide the rest of the history.
+func (s *Store) Since(t time.Time) ([]Record, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if !record.Time.Before(t) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return records, nil
+}
+
+// MonthToDate returns the estimated cost of the requests made since the start of the
+// calendar month containing now
+func (s *Store) MonthToDate(now time.Time) (float64, error) {
+	records, err := s.Since(MonthStart(now))
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, r := range records {
+		total += r.Cost
+	}
+	return total, nil
+}
+
+// MonthStart returns midnight on the first day of t's month, in t's location
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// ParseSince parses a report start as a number of days (30d), a Go duration (12h) or a
+// date (2024-01-31), relative to now
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a number of days (30d), a duration (12h) or a date (2024-01-31)", value)
+}
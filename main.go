@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 
@@ -9,8 +10,18 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/dacsang97/aigc/cmd"
+	cmdcache "github.com/dacsang97/aigc/cmd/cache"
+	cmdchangelog "github.com/dacsang97/aigc/cmd/changelog"
 	cmdcommit "github.com/dacsang97/aigc/cmd/commit"
 	cmdconfig "github.com/dacsang97/aigc/cmd/config"
+	cmdmodels "github.com/dacsang97/aigc/cmd/models"
+	cmdpr "github.com/dacsang97/aigc/cmd/pr"
+	cmdrelease "github.com/dacsang97/aigc/cmd/release"
+	cmdreword "github.com/dacsang97/aigc/cmd/reword"
+	cmdsplit "github.com/dacsang97/aigc/cmd/split"
+	cmdsquash "github.com/dacsang97/aigc/cmd/squash"
+	cmdtemplate "github.com/dacsang97/aigc/cmd/template"
+	cmdusage "github.com/dacsang97/aigc/cmd/usage"
 	"github.com/dacsang97/aigc/internal/config"
 	"github.com/dacsang97/aigc/internal/logger"
 )
@@ -46,6 +57,16 @@ func main() {
 	commands := []cmd.Command{
 		cmdconfig.New(configManager, appLogger),
 		cmdcommit.New(configManager, appLogger),
+		cmdreword.New(configManager, appLogger),
+		cmdsplit.New(configManager, appLogger),
+		cmdsquash.New(configManager, appLogger),
+		cmdpr.New(configManager, appLogger),
+		cmdchangelog.New(configManager, appLogger),
+		cmdrelease.New(configManager, appLogger),
+		cmdtemplate.New(configManager, appLogger),
+		cmdcache.New(configManager, appLogger),
+		cmdusage.New(configManager, appLogger),
+		cmdmodels.New(configManager, appLogger),
 	}
 
 	// Convert commands to cli.Commands
@@ -74,6 +95,11 @@ func main() {
 	}
 
 	if err := app.Run(os.Args); err != nil {
-		appLogger.Fatal("application error", zap.Error(err))
+		// Log the error, then show it: git's stderr and provider messages would
+		// otherwise only end up in the log file
+		appLogger.Error("application error", zap.Error(err))
+		appLogger.Sync()
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 }